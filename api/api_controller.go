@@ -676,6 +676,9 @@ func (c *Controller) CreateBranchHandler() branches.CreateBranchHandler {
 		cataloger := deps.Cataloger
 		sourceBranch := swag.StringValue(params.Branch.Source)
 		commitLog, err := cataloger.CreateBranch(c.Context(), repository, branch, sourceBranch)
+		if errors.Is(err, catalog.ErrBranchAlreadyExists) {
+			return branches.NewCreateBranchDefault(http.StatusConflict).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return branches.NewCreateBranchDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -696,11 +699,15 @@ func (c *Controller) DeleteBranchHandler() branches.DeleteBranchHandler {
 		}
 		deps.LogAction("delete_branch")
 		cataloger := deps.Cataloger
-		err = cataloger.DeleteBranch(c.Context(), params.Repository, params.Branch)
+		err = cataloger.DeleteBranch(c.Context(), params.Repository, params.Branch, swag.BoolValue(params.Force))
 		if errors.Is(err, db.ErrNotFound) {
 			return branches.NewDeleteBranchNotFound().
 				WithPayload(responseError("branch '%s' not found", params.Branch))
 		}
+		if errors.Is(err, catalog.ErrBranchHasUncommittedChanges) || errors.Is(err, catalog.ErrCannotDeleteDefaultBranch) ||
+			errors.Is(err, catalog.ErrBranchHasTags) {
+			return branches.NewDeleteBranchConflict().WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return branches.NewDeleteBranchDefault(http.StatusInternalServerError).
 				WithPayload(responseError("error fetching branch: %s", err))
@@ -738,6 +745,9 @@ func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 			message,
 			metadata)
 
+		if errors.Is(err, catalog.ErrFeatureNotSupported) {
+			return refs.NewMergeIntoBranchDefault(http.StatusNotImplemented).WithPayload(responseError(err.Error()))
+		}
 		switch err {
 		case nil:
 			payload := newMergeResultFromCatalog(res)
@@ -778,6 +788,7 @@ func newMergeResultFromCatalog(res *catalog.MergeResult) *models.MergeResult {
 	return &models.MergeResult{
 		Reference: res.Reference,
 		Summary:   &summary,
+		Conflicts: res.Conflicts,
 	}
 }
 
@@ -796,7 +807,7 @@ func (c *Controller) BranchesDiffBranchHandler() branches.DiffBranchHandler {
 		cataloger := deps.Cataloger
 		limit := int(swag.Int64Value(params.Amount))
 		after := swag.StringValue(params.After)
-		diff, hasMore, err := cataloger.DiffUncommitted(c.Context(), params.Repository, params.Branch, limit, after)
+		diff, hasMore, err := cataloger.DiffUncommitted(c.Context(), params.Repository, params.Branch, "", limit, after)
 		if err != nil {
 			return branches.NewDiffBranchDefault(http.StatusInternalServerError).
 				WithPayload(responseError("could not diff branch: %s", err))
@@ -1284,11 +1295,11 @@ func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 		case models.RevertCreationTypeCommit:
 			err = cataloger.RollbackCommit(ctx, params.Repository, params.Revert.Commit)
 		case models.RevertCreationTypeCommonPrefix:
-			err = cataloger.ResetEntries(ctx, params.Repository, params.Branch, params.Revert.Path)
+			_, err = cataloger.ResetEntries(ctx, params.Repository, params.Branch, params.Revert.Path)
 		case models.RevertCreationTypeReset:
-			err = cataloger.ResetBranch(ctx, params.Repository, params.Branch)
+			_, err = cataloger.ResetBranch(ctx, params.Repository, params.Branch)
 		case models.RevertCreationTypeObject:
-			err = cataloger.ResetEntry(ctx, params.Repository, params.Branch, params.Revert.Path)
+			err = cataloger.ResetEntryStrict(ctx, params.Repository, params.Branch, params.Revert.Path)
 		default:
 			return branches.NewRevertBranchNotFound().
 				WithPayload(responseError("revert type not found"))
@@ -676,6 +676,44 @@ func TestHandler_DeleteBranchHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("delete branch with uncommitted changes", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := deps.cataloger.CreateBranch(ctx, "my-new-repo", "master3", "master")
+		testutil.Must(t, err)
+		testutil.Must(t, deps.cataloger.CreateEntry(ctx, "my-new-repo", "master3",
+			catalog.Entry{Path: "a/b", PhysicalAddress: "xx", Checksum: "cc", Size: 1}, catalog.CreateEntryParams{}))
+
+		_, err = clt.Branches.DeleteBranch(&branches.DeleteBranchParams{
+			Branch:     "master3",
+			Repository: "my-new-repo",
+		}, bauth)
+		var conflictErr *branches.DeleteBranchConflict
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("expected conflict deleting branch with uncommitted changes, got %v", err)
+		}
+
+		_, err = clt.Branches.DeleteBranch(&branches.DeleteBranchParams{
+			Branch:     "master3",
+			Repository: "my-new-repo",
+			Force:      swag.Bool(true),
+		}, bauth)
+		if err != nil {
+			t.Fatalf("unexpected error force deleting branch: %s", err)
+		}
+	})
+
+	t.Run("delete default branch", func(t *testing.T) {
+		_, err := clt.Branches.DeleteBranch(&branches.DeleteBranchParams{
+			Branch:     "master",
+			Repository: "my-new-repo",
+			Force:      swag.Bool(true),
+		}, bauth)
+		var conflictErr *branches.DeleteBranchConflict
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("expected conflict deleting default branch, got %v", err)
+		}
+	})
+
 	t.Run("delete branch doesnt exist", func(t *testing.T) {
 		_, err := clt.Branches.DeleteBranch(&branches.DeleteBranchParams{
 			Branch:     "master5",
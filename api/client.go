@@ -62,7 +62,7 @@ type RepositoryClient interface {
 	ListBranches(ctx context.Context, repository string, from string, amount int) ([]string, *models.Pagination, error)
 	GetBranch(ctx context.Context, repository, branchID string) (string, error)
 	CreateBranch(ctx context.Context, repository string, branch *models.BranchCreation) (string, error)
-	DeleteBranch(ctx context.Context, repository, branchID string) error
+	DeleteBranch(ctx context.Context, repository, branchID string, force bool) error
 	RevertBranch(ctx context.Context, repository, branchID string, revertProps *models.RevertCreation) error
 
 	Commit(ctx context.Context, repository, branchID, message string, metadata map[string]string) (*models.Commit, error)
@@ -465,10 +465,11 @@ func (c *client) CreateBranch(ctx context.Context, repository string, branch *mo
 	return resp.GetPayload(), nil
 }
 
-func (c *client) DeleteBranch(ctx context.Context, repository, branchID string) error {
+func (c *client) DeleteBranch(ctx context.Context, repository, branchID string, force bool) error {
 	_, err := c.remote.Branches.DeleteBranch(&branches.DeleteBranchParams{
 		Branch:     branchID,
 		Repository: repository,
+		Force:      swag.Bool(force),
 		Context:    ctx,
 	}, c.auth)
 	return err
@@ -80,6 +80,17 @@ type Credential struct {
 	AccessSecretKeyEncryptedBytes []byte    `db:"access_secret_key" json:"-"`
 	IssuedDate                    time.Time `db:"issued_date"`
 	UserID                        int       `db:"user_id"`
+	// SessionToken is set only for temporary credentials, requests must present it to authenticate
+	SessionToken string `json:"-"`
+	// PreviousAccessSecretKeys are secret keys replaced by AccessSecretKey that remain valid while the key is rotated
+	PreviousAccessSecretKeys []string `json:"-"`
+}
+
+// GetAccessSecretKeys returns every secret key requests signed with the access key may use, AccessSecretKey first
+func (c *Credential) GetAccessSecretKeys() []string {
+	keys := make([]string, 0, 1+len(c.PreviousAccessSecretKeys))
+	keys = append(keys, c.AccessSecretKey)
+	return append(keys, c.PreviousAccessSecretKeys...)
 }
 
 // For JSON serialization:
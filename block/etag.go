@@ -0,0 +1,42 @@
+package block
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+var ErrInvalidPartETag = errors.New("invalid part etag")
+
+// ComputeMultipartETag returns the ETag S3 assigns to an object assembled from parts: the MD5 of the concatenated
+// binary MD5s of the parts, followed by "-" and the number of parts.  Part ETags may be quoted and must be the hex
+// MD5 of the part content, as S3 returns for parts uploaded without server side encryption.
+// The result is not quoted, format it with httputil.ETag for use in a header.
+func ComputeMultipartETag(parts []*s3.CompletedPart) (string, error) {
+	partETags := make([]string, len(parts))
+	for i, part := range parts {
+		if part.ETag == nil {
+			return "", fmt.Errorf("part at position %d: %w", i, ErrInvalidPartETag)
+		}
+		partETags[i] = *part.ETag
+	}
+	return ComputeMultipartETagFromETags(partETags)
+}
+
+// ComputeMultipartETagFromETags is ComputeMultipartETag for parts given by their ETags.
+func ComputeMultipartETagFromETags(partETags []string) (string, error) {
+	h := md5.New() //nolint:gosec
+	for i, etag := range partETags {
+		partMD5, err := hex.DecodeString(strings.Trim(etag, `"`))
+		if err != nil || len(partMD5) != md5.Size {
+			return "", fmt.Errorf("part at position %d: %w", i, ErrInvalidPartETag)
+		}
+		_, _ = h.Write(partMD5)
+	}
+	return hex.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(partETags)), nil
+}
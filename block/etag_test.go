@@ -0,0 +1,87 @@
+package block_test
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/treeverse/lakefs/block"
+)
+
+func TestComputeMultipartETag(t *testing.T) {
+	partETag := func(data string) string {
+		sum := md5.Sum([]byte(data)) //nolint:gosec
+		return hex.EncodeToString(sum[:])
+	}
+	tests := []struct {
+		name      string
+		partETags []string
+		etag      string
+		err       error
+	}{
+		{
+			name:      "single part",
+			partETags: []string{partETag("hello world")},
+			etag:      "241d8a27c836427bd7f04461b60e7359-1",
+		},
+		{
+			name:      "two parts",
+			partETags: []string{partETag(strings.Repeat("a", 5*1024*1024)), partETag("hello world")},
+			etag:      "4ba54d90a25d93d94b7b41c987352341-2",
+		},
+		{
+			name:      "quoted part etags",
+			partETags: []string{`"79b281060d337b9b2b84ccf390adcf74"`, `"5eb63bbbe01eeed093cb22bb8f5acdc3"`},
+			etag:      "4ba54d90a25d93d94b7b41c987352341-2",
+		},
+		{
+			name:      "not hex",
+			partETags: []string{"not-an-md5"},
+			err:       block.ErrInvalidPartETag,
+		},
+		{
+			name:      "not md5",
+			partETags: []string{"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
+			err:       block.ErrInvalidPartETag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := make([]*s3.CompletedPart, len(tt.partETags))
+			for i, etag := range tt.partETags {
+				parts[i] = &s3.CompletedPart{ETag: aws.String(etag), PartNumber: aws.Int64(int64(i + 1))}
+			}
+			etag, err := block.ComputeMultipartETag(parts)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ComputeMultipartETag() err = %v, expected %v", err, tt.err)
+			}
+			if etag != tt.etag {
+				t.Errorf("ComputeMultipartETag() = %s, expected %s", etag, tt.etag)
+			}
+		})
+	}
+}
+
+func TestComputeMultipartETagFromETags(t *testing.T) {
+	const partsCount = 30
+	partETags := make([]string, partsCount)
+	for i := range partETags {
+		var partMD5 [md5.Size]byte
+		for j := range partMD5 {
+			partMD5[j] = byte(32 + i + j)
+		}
+		partETags[i] = hex.EncodeToString(partMD5[:])
+	}
+	const expected = "9cae1a3b7e97542c261cf2e1b50ba482-30"
+	etag, err := block.ComputeMultipartETagFromETags(partETags)
+	if err != nil {
+		t.Fatalf("ComputeMultipartETagFromETags() err = %s", err)
+	}
+	if etag != expected {
+		t.Errorf("ComputeMultipartETagFromETags() = %s, expected %s", etag, expected)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/md5" //nolint:gosec
 	"crypto/sha256"
 	"hash"
+	"hash/crc32"
 	"io"
 	"strconv"
 )
@@ -11,11 +12,13 @@ import (
 const (
 	HashFunctionMD5 = iota
 	HashFunctionSHA256
+	HashFunctionCRC32C
 )
 
 type HashingReader struct {
 	Md5            hash.Hash
 	Sha256         hash.Hash
+	Crc32c         hash.Hash
 	originalReader io.Reader
 	CopiedSize     int64
 }
@@ -33,13 +36,18 @@ func (s *HashingReader) Read(p []byte) (int, error) {
 			return nb, err2
 		}
 	}
+	if s.Crc32c != nil {
+		if _, err2 := s.Crc32c.Write(p[0:nb]); err2 != nil {
+			return nb, err2
+		}
+	}
 	return nb, err
 }
 
 func NewHashingReader(body io.Reader, hashTypes ...int) *HashingReader {
 	s := new(HashingReader)
 	s.originalReader = body
-	for hashType := range hashTypes {
+	for _, hashType := range hashTypes {
 		switch hashType {
 		case HashFunctionMD5:
 			if s.Md5 == nil {
@@ -49,6 +57,10 @@ func NewHashingReader(body io.Reader, hashTypes ...int) *HashingReader {
 			if s.Sha256 == nil {
 				s.Sha256 = sha256.New()
 			}
+		case HashFunctionCRC32C:
+			if s.Crc32c == nil {
+				s.Crc32c = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+			}
 		default:
 			panic("wrong hash type number " + strconv.Itoa(hashType))
 		}
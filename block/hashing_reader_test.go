@@ -0,0 +1,67 @@
+package block_test
+
+import (
+	"encoding/hex"
+	"hash"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/block"
+)
+
+func TestHashingReader(t *testing.T) {
+	const data = "The quick brown fox jumps over the lazy dog"
+	tests := []struct {
+		name      string
+		hashTypes []int
+		md5       string
+		sha256    string
+		crc32c    string
+	}{
+		{
+			name:      "all",
+			hashTypes: []int{block.HashFunctionMD5, block.HashFunctionSHA256, block.HashFunctionCRC32C},
+			md5:       "9e107d9d372bb6826bd81d3542a419d6",
+			sha256:    "d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592",
+			crc32c:    "22620404",
+		},
+		{
+			name:      "crc32c only",
+			hashTypes: []int{block.HashFunctionCRC32C},
+			crc32c:    "22620404",
+		},
+		{
+			name:      "sha256 only",
+			hashTypes: []int{block.HashFunctionSHA256},
+			sha256:    "d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := block.NewHashingReader(strings.NewReader(data), tt.hashTypes...)
+			if _, err := ioutil.ReadAll(r); err != nil {
+				t.Fatal("read all:", err)
+			}
+			if r.CopiedSize != int64(len(data)) {
+				t.Errorf("CopiedSize = %d, expected %d", r.CopiedSize, len(data))
+			}
+			if got := hexSum(r.Md5); got != tt.md5 {
+				t.Errorf("md5 = %s, expected %s", got, tt.md5)
+			}
+			if got := hexSum(r.Sha256); got != tt.sha256 {
+				t.Errorf("sha256 = %s, expected %s", got, tt.sha256)
+			}
+			if got := hexSum(r.Crc32c); got != tt.crc32c {
+				t.Errorf("crc32c = %s, expected %s", got, tt.crc32c)
+			}
+		})
+	}
+}
+
+func hexSum(h hash.Hash) string {
+	if h == nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
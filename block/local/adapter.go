@@ -2,7 +2,6 @@ package local
 
 import (
 	"context"
-	"crypto/md5" //nolint:gosec
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -13,10 +12,8 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/google/uuid"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/logging"
@@ -174,7 +171,10 @@ func (l *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string)
 }
 
 func (l *Adapter) CompleteMultiPartUpload(obj block.ObjectPointer, uploadID string, multipartList *block.MultipartUploadCompletion) (*string, int64, error) {
-	etag := computeETag(multipartList.Part) + "-" + strconv.Itoa(len(multipartList.Part))
+	etag, err := block.ComputeMultipartETag(multipartList.Part)
+	if err != nil {
+		return nil, -1, fmt.Errorf("multipart upload etag for %s: %w", uploadID, err)
+	}
 	partFiles, err := l.getPartFiles(uploadID)
 	if err != nil {
 		return nil, -1, fmt.Errorf("part files not found for %s: %w", uploadID, err)
@@ -187,22 +187,6 @@ func (l *Adapter) CompleteMultiPartUpload(obj block.ObjectPointer, uploadID stri
 	return &etag, size, nil
 }
 
-func computeETag(parts []*s3.CompletedPart) string {
-	var etagHex []string
-	for _, p := range parts {
-		e := *p.ETag
-		if strings.HasPrefix(e, "\"") && strings.HasSuffix(e, "\"") {
-			e = e[1 : len(e)-1]
-		}
-		etagHex = append(etagHex, e)
-	}
-	s := strings.Join(etagHex, "")
-	b, _ := hex.DecodeString(s)
-	md5res := md5.Sum(b) //nolint:gosec
-	csm := hex.EncodeToString(md5res[:])
-	return csm
-}
-
 func (l *Adapter) unitePartFiles(identifier string, files []string) (int64, error) {
 	p := l.getPath(identifier)
 	unitedFile, err := os.Create(p)
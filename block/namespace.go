@@ -26,6 +26,22 @@ type QualifiedKey struct {
 	Key              string
 }
 
+// Format returns the URI of the qualified key, as in "s3://bucket/path/to/object"
+func (qk QualifiedKey) Format() string {
+	var scheme string
+	switch qk.StorageType {
+	case StorageTypeS3:
+		scheme = "s3"
+	case StorageTypeGS:
+		scheme = "gs"
+	case StorageTypeLocal:
+		scheme = "local"
+	default:
+		scheme = "mem"
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, qk.StorageNamespace, qk.Key)
+}
+
 func GetStorageType(namespaceURL *url.URL) (StorageType, error) {
 	var st StorageType
 	switch namespaceURL.Scheme {
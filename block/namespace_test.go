@@ -95,3 +95,29 @@ func TestResolveNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestQualifiedKey_Format(t *testing.T) {
+	cases := []struct {
+		Name             string
+		DefaultNamespace string
+		Key              string
+		Expected         string
+	}{
+		{Name: "s3", DefaultNamespace: "s3://foo/", Key: "bar/baz", Expected: "s3://foo/bar/baz"},
+		{Name: "s3_namespace_path", DefaultNamespace: "s3://foo/ns", Key: "bar", Expected: "s3://foo/ns/bar"},
+		{Name: "gs", DefaultNamespace: "gs://foo", Key: "bar", Expected: "gs://foo/bar"},
+		{Name: "fq_key", DefaultNamespace: "mem://foo/", Key: "s3://example/bar/baz", Expected: "s3://example/bar/baz"},
+	}
+
+	for _, cas := range cases {
+		t.Run(cas.Name, func(t *testing.T) {
+			resolved, err := block.ResolveNamespace(cas.DefaultNamespace, cas.Key)
+			if err != nil {
+				t.Fatalf("got unexpected error :%v", err)
+			}
+			if got := resolved.Format(); got != cas.Expected {
+				t.Fatalf("expected %s got %s", cas.Expected, got)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ type SetFn func() (v interface{}, err error)
 
 type Cache interface {
 	GetOrSet(k interface{}, setFn SetFn) (v interface{}, err error)
+	// RemoveIf removes the keys for which match returns true
+	RemoveIf(match func(k interface{}) bool)
 }
 
 type GetSetCache struct {
@@ -61,6 +63,14 @@ func (c *GetSetCache) GetOrSet(k interface{}, setFn SetFn) (v interface{}, err e
 	return nil, ErrCacheItemNotFound
 }
 
+func (c *GetSetCache) RemoveIf(match func(k interface{}) bool) {
+	for _, k := range c.lru.Keys() {
+		if match(k) {
+			c.lru.Remove(k)
+		}
+	}
+}
+
 func NewJitterFn(jitter time.Duration) JitterFn {
 	return func() time.Duration {
 		n := rand.Intn(int(jitter)) //nolint:gosec
@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"strings"
 	"time"
 
 	"github.com/treeverse/lakefs/cache"
@@ -9,26 +10,46 @@ import (
 type GetRepositoryFn func(repository string) (*Repository, error)
 type GetRepositoryIDFn func(repository string) (int, error)
 type GetBranchIDFn func(repository string, branch string) (int64, error)
+type GetCommittedEntryFn func(repository string, ref Ref, path string) (*Entry, error)
 
 type Cache interface {
 	Repository(repository string, setFn GetRepositoryFn) (*Repository, error)
 	RepositoryID(repository string, setFn GetRepositoryIDFn) (int, error)
 	BranchID(repository string, branch string, setFn GetBranchIDFn) (int64, error)
+	// CommittedEntry returns the entry of path as of ref, which must reference a specific commit
+	CommittedEntry(repository string, ref Ref, path string, setFn GetCommittedEntryFn) (*Entry, error)
+	// EvictRepository removes everything cached for repository, call it after the repository is deleted or restored
+	EvictRepository(repository string)
 }
 
 type LRUCache struct {
 	repository   cache.Cache
 	repositoryID cache.Cache
 	branchID     cache.Cache
+	// committedEntry is nil when committed entries are not cached
+	committedEntry cache.Cache
 }
 
-func NewLRUCache(size int, expiry, jitter time.Duration) *LRUCache {
+type committedEntryKey struct {
+	repository string
+	branch     string
+	commitID   CommitID
+	path       string
+}
+
+// NewLRUCache returns a cache holding up to size repositories and branches, and up to entriesSize committed
+// entries.  Committed entries are not cached when entriesSize is zero.
+func NewLRUCache(size, entriesSize int, expiry, jitter time.Duration) *LRUCache {
 	jitterFn := cache.NewJitterFn(jitter)
-	return &LRUCache{
+	c := &LRUCache{
 		repository:   cache.NewCache(size, expiry, jitterFn),
 		repositoryID: cache.NewCache(size, expiry, jitterFn),
 		branchID:     cache.NewCache(size, expiry, jitterFn),
 	}
+	if entriesSize > 0 {
+		c.committedEntry = cache.NewCache(entriesSize, expiry, jitterFn)
+	}
+	return c
 }
 
 func (c *LRUCache) Repository(repository string, setFn GetRepositoryFn) (*Repository, error) {
@@ -62,6 +83,68 @@ func (c *LRUCache) BranchID(repository string, branch string, setFn GetBranchIDF
 	return v.(int64), nil
 }
 
+// CommittedEntry caches the entry of path at a specific commit.  Entries of a commit do not change, except for
+// their expired flag which is set by retention, so they are kept until the cache expiry.
+// Each call returns a copy of the cached entry, which the caller may modify.
+func (c *LRUCache) CommittedEntry(repository string, ref Ref, path string, setFn GetCommittedEntryFn) (*Entry, error) {
+	if c.committedEntry == nil {
+		return setFn(repository, ref, path)
+	}
+	key := committedEntryKey{
+		repository: repository,
+		branch:     ref.Branch,
+		commitID:   ref.CommitID,
+		path:       path,
+	}
+	v, err := c.committedEntry.GetOrSet(key, func() (interface{}, error) {
+		return setFn(repository, ref, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyEntry(v.(*Entry)), nil
+}
+
+// copyEntry returns a deep copy of entry, sharing nothing the caller may modify with it
+func copyEntry(entry *Entry) *Entry {
+	c := *entry
+	if entry.Metadata != nil {
+		c.Metadata = make(Metadata, len(entry.Metadata))
+		for k, v := range entry.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	if entry.Checksums != nil {
+		c.Checksums = make(Checksums, len(entry.Checksums))
+		for k, v := range entry.Checksums {
+			c.Checksums[k] = v
+		}
+	}
+	if entry.ExpiresAt != nil {
+		expiresAt := *entry.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	return &c
+}
+
+func (c *LRUCache) EvictRepository(repository string) {
+	c.repository.RemoveIf(func(k interface{}) bool {
+		return k == repository
+	})
+	c.repositoryID.RemoveIf(func(k interface{}) bool {
+		return k == repository
+	})
+	branchPrefix := repository + "/"
+	c.branchID.RemoveIf(func(k interface{}) bool {
+		return strings.HasPrefix(k.(string), branchPrefix)
+	})
+	if c.committedEntry != nil {
+		c.committedEntry.RemoveIf(func(k interface{}) bool {
+			return k.(committedEntryKey).repository == repository
+		})
+	}
+}
+
 type DummyCache struct{}
 
 func (c *DummyCache) Repository(repository string, setFn GetRepositoryFn) (*Repository, error) {
@@ -75,3 +158,9 @@ func (c *DummyCache) RepositoryID(repository string, setFn GetRepositoryIDFn) (i
 func (c *DummyCache) BranchID(repository string, branch string, setFn GetBranchIDFn) (int64, error) {
 	return setFn(repository, branch)
 }
+
+func (c *DummyCache) CommittedEntry(repository string, ref Ref, path string, setFn GetCommittedEntryFn) (*Entry, error) {
+	return setFn(repository, ref, path)
+}
+
+func (c *DummyCache) EvictRepository(string) {}
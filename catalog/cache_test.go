@@ -0,0 +1,152 @@
+package catalog
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLRUCache_CommittedEntry(t *testing.T) {
+	const (
+		repository = "repo"
+		path       = "file1"
+	)
+	reads := 0
+	readFn := func(repository string, ref Ref, path string) (*Entry, error) {
+		reads++
+		expiresAt := time.Unix(1600000000, 0)
+		return &Entry{
+			Path:            path,
+			PhysicalAddress: ref.String(),
+			Size:            int64(ref.CommitID),
+			Metadata:        Metadata{"key": "value"},
+			Checksums:       Checksums{ChecksumAlgorithmSHA256: "abc"},
+			ExpiresAt:       &expiresAt,
+		}, nil
+	}
+	c := NewLRUCache(10, 10, time.Minute, time.Second)
+
+	ref := Ref{Branch: "master", CommitID: 1}
+	for i := 0; i < 3; i++ {
+		entry, err := c.CommittedEntry(repository, ref, path, readFn)
+		if err != nil {
+			t.Fatalf("CommittedEntry() read %d err = %s", i, err)
+		}
+		if entry.Path != path || entry.PhysicalAddress != ref.String() ||
+			entry.Metadata["key"] != "value" || entry.Checksums[ChecksumAlgorithmSHA256] != "abc" || entry.ExpiresAt.Unix() != 1600000000 {
+			t.Fatalf("CommittedEntry() read %d = %+v, expected entry of %s at %s", i, entry, path, ref)
+		}
+		// callers may modify the entry they get without changing the cached entry
+		entry.Path = "modified"
+		entry.Metadata["key"] = "modified"
+		entry.Checksums[ChecksumAlgorithmSHA256] = "modified"
+		*entry.ExpiresAt = time.Time{}
+	}
+	if reads != 1 {
+		t.Fatalf("CommittedEntry() read %d times for the same entry, expected 1", reads)
+	}
+
+	otherRef := Ref{Branch: "master", CommitID: 2}
+	entry, err := c.CommittedEntry(repository, otherRef, path, readFn)
+	if err != nil {
+		t.Fatalf("CommittedEntry() of other commit err = %s", err)
+	}
+	if entry.Size != int64(otherRef.CommitID) {
+		t.Fatalf("CommittedEntry() of other commit = %+v, expected entry at %s", entry, otherRef)
+	}
+	if reads != 2 {
+		t.Fatalf("CommittedEntry() read %d times for two entries, expected 2", reads)
+	}
+}
+
+func TestLRUCache_CommittedEntryError(t *testing.T) {
+	errRead := errors.New("read failed")
+	reads := 0
+	readFn := func(repository string, ref Ref, path string) (*Entry, error) {
+		reads++
+		if reads == 1 {
+			return nil, errRead
+		}
+		return &Entry{Path: path}, nil
+	}
+	c := NewLRUCache(10, 10, time.Minute, time.Second)
+	ref := Ref{Branch: "master", CommitID: 1}
+	if _, err := c.CommittedEntry("repo", ref, "file1", readFn); !errors.Is(err, errRead) {
+		t.Fatalf("CommittedEntry() err = %v, expected %s", err, errRead)
+	}
+	// failed reads are not cached
+	if _, err := c.CommittedEntry("repo", ref, "file1", readFn); err != nil {
+		t.Fatalf("CommittedEntry() after failed read err = %s", err)
+	}
+	if reads != 2 {
+		t.Fatalf("CommittedEntry() read %d times, expected 2", reads)
+	}
+}
+
+func TestLRUCache_CommittedEntryDisabled(t *testing.T) {
+	reads := 0
+	readFn := func(repository string, ref Ref, path string) (*Entry, error) {
+		reads++
+		return &Entry{Path: path}, nil
+	}
+	c := NewLRUCache(10, 0, time.Minute, time.Second)
+	ref := Ref{Branch: "master", CommitID: 1}
+	const readsCount = 3
+	for i := 0; i < readsCount; i++ {
+		if _, err := c.CommittedEntry("repo", ref, "file1", readFn); err != nil {
+			t.Fatalf("CommittedEntry() read %d err = %s", i, err)
+		}
+	}
+	if reads != readsCount {
+		t.Fatalf("CommittedEntry() read %d times without entries cache, expected %d", reads, readsCount)
+	}
+}
+
+func BenchmarkLRUCache_CommittedEntry(b *testing.B) {
+	const paths = 100
+	for _, entriesSize := range []int{0, paths} {
+		b.Run("entries_size_"+strconv.Itoa(entriesSize), func(b *testing.B) {
+			reads := 0
+			readFn := func(repository string, ref Ref, path string) (*Entry, error) {
+				reads++
+				return &Entry{Path: path}, nil
+			}
+			c := NewLRUCache(10, entriesSize, time.Minute, time.Second)
+			ref := Ref{Branch: "master", CommitID: 1}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.CommittedEntry("repo", ref, "file"+strconv.Itoa(i%paths), readFn)
+			}
+			b.ReportMetric(float64(reads)/float64(b.N), "reads/op")
+		})
+	}
+}
+
+func TestLRUCache_EvictRepository(t *testing.T) {
+	c := NewLRUCache(10, 10, time.Minute, time.Second)
+	reads := 0
+	branchIDFn := func(repository string, branch string) (int64, error) {
+		reads++
+		return int64(reads), nil
+	}
+	for _, repository := range []string{"repo", "repo2"} {
+		if _, err := c.BranchID(repository, "master", branchIDFn); err != nil {
+			t.Fatalf("BranchID(%s) err = %s", repository, err)
+		}
+	}
+
+	c.EvictRepository("repo")
+	if _, err := c.BranchID("repo2", "master", branchIDFn); err != nil {
+		t.Fatalf("BranchID() of other repository err = %s", err)
+	}
+	if reads != 2 {
+		t.Fatalf("BranchID() of other repository read after evict, reads = %d, expected 2", reads)
+	}
+	if _, err := c.BranchID("repo", "master", branchIDFn); err != nil {
+		t.Fatalf("BranchID() of evicted repository err = %s", err)
+	}
+	if reads != 3 {
+		t.Fatalf("BranchID() of evicted repository not read after evict, reads = %d, expected 3", reads)
+	}
+}
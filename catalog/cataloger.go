@@ -34,6 +34,8 @@ const (
 	defaultBatchReaders           = 8
 
 	defaultBatchWriteEntriesInsertSize = 10
+
+	defaultRepositoryDeleteRetention = 7 * 24 * time.Hour
 )
 
 type DedupReport struct {
@@ -61,16 +63,27 @@ type RepositoryCataloger interface {
 	CreateRepository(ctx context.Context, repository string, storageNamespace string, branch string) error
 	GetRepository(ctx context.Context, repository string) (*Repository, error)
 	DeleteRepository(ctx context.Context, repository string) error
+	UndeleteRepository(ctx context.Context, repository string) error
+	PurgeDeletedRepositories(ctx context.Context) (int64, error)
 	ListRepositories(ctx context.Context, limit int, after string) ([]*Repository, bool, error)
 }
 
 type BranchCataloger interface {
 	CreateBranch(ctx context.Context, repository, branch string, sourceBranch string) (*CommitLog, error)
-	DeleteBranch(ctx context.Context, repository, branch string) error
+	// DeleteBranch deletes branch, refusing the default branch and, unless force is set, a branch with
+	// uncommitted changes.
+	DeleteBranch(ctx context.Context, repository, branch string, force bool) error
 	ListBranches(ctx context.Context, repository string, prefix string, limit int, after string) ([]*Branch, bool, error)
+	// ListBranchTips lists the branches of repository after the given name, in name order, with the reference
+	// and creation date of their last commit and whether they have uncommitted changes.
+	ListBranchTips(ctx context.Context, repository string, limit int, after string) ([]*BranchTip, bool, error)
 	BranchExists(ctx context.Context, repository string, branch string) (bool, error)
 	GetBranchReference(ctx context.Context, repository, branch string) (string, error)
-	ResetBranch(ctx context.Context, repository, branch string) error
+	ResetBranch(ctx context.Context, repository, branch string) (int64, error)
+	// ResetBranchToRef rewinds branch to targetRef, dropping its later commits and uncommitted changes.
+	// Unless force is set, targetRef must be an ancestor of the branch.
+	ResetBranchToRef(ctx context.Context, repository, branch, targetRef string, force bool) (string, error)
+	HasUncommittedChanges(ctx context.Context, repository, branch string) (bool, error)
 }
 
 var ErrExpired = errors.New("expired from storage")
@@ -100,12 +113,41 @@ type EntryCataloger interface {
 	// GetEntry returns the current entry for path in repository branch reference.  Returns
 	// the entry with ExpiredError if it has expired from underlying storage.
 	GetEntry(ctx context.Context, repository, reference string, path string, params GetEntryParams) (*Entry, error)
+	// GetPhysicalAddress returns the physical address and checksum of the object at path in ref.  Returns
+	// ErrEntryNotFound if there is no object at path.
+	GetPhysicalAddress(ctx context.Context, repository, ref, path string) (string, string, error)
+	// StatEntry returns the size, checksum, modification time and content type of the object at path in ref,
+	// without reading the rest of the entry.  Returns ErrEntryNotFound if there is no object at path.
+	StatEntry(ctx context.Context, repository, ref, path string) (EntryStat, error)
 	CreateEntry(ctx context.Context, repository, branch string, entry Entry, params CreateEntryParams) error
 	CreateEntries(ctx context.Context, repository, branch string, entries []Entry) error
+	// PutEntries writes entries to branch in a single transaction.  Returns the number of entries written and
+	// the entries skipped because they failed validation.
+	PutEntries(ctx context.Context, repository, branch string, entries []Entry) (int, []*EntryFailure, error)
+	// PutEntryIf writes entry to path on branch only if the current entry satisfies condition, otherwise
+	// returns ErrPreconditionFailed.
+	PutEntryIf(ctx context.Context, repository, branch, path string, entry Entry, condition EntryCondition) error
 	DeleteEntry(ctx context.Context, repository, branch string, path string) error
+	// CopyEntry creates an uncommitted entry at destPath on destBranch pointing to the object of srcPath on
+	// srcBranch, without copying any data.
+	CopyEntry(ctx context.Context, repository, srcBranch, srcPath, destBranch, destPath string) (*Entry, error)
+	// ListEntries lists the entries under prefix after the path after.  With DefaultPathDelimiter as delimiter,
+	// paths sharing a prefix up to the next delimiter are rolled up to a single entry with CommonLevel set.
+	// Objects and common prefixes are paginated together, in path order.
 	ListEntries(ctx context.Context, repository, reference string, prefix, after string, delimiter string, limit int) ([]*Entry, bool, error)
+	// ListExpiredEntries lists the entries of branch whose ExpiresAt is not after now, which ListEntries no
+	// longer shows, in path order.
+	ListExpiredEntries(ctx context.Context, repository, branch string, now time.Time, limit int, after string) ([]*Entry, bool, error)
+	// ExportManifest writes a newline-delimited JSON manifest of the objects committed in ref to w, sorted by path.
+	ExportManifest(ctx context.Context, repository, ref string, w io.Writer) error
+	// ExportSymlinkManifest writes the storage URIs of the objects under prefix committed in ref to w, one per
+	// line, as a symlink.txt manifest for query engines.
+	ExportSymlinkManifest(ctx context.Context, repository, ref, prefix string, w io.Writer) error
 	ResetEntry(ctx context.Context, repository, branch string, path string) error
-	ResetEntries(ctx context.Context, repository, branch string, prefix string) error
+	ResetEntryStrict(ctx context.Context, repository, branch string, path string) error
+	ResetEntries(ctx context.Context, repository, branch string, prefix string) (int64, error)
+	ResetEntriesByPaths(ctx context.Context, repository, branch string, paths []string) (map[string]bool, error)
+	ResetEntriesPreview(ctx context.Context, repository, branch string, prefix string, limit int, after string) ([]string, bool, error)
 
 	// QueryEntriesToExpire returns ExpiryRows iterating over all objects to expire on
 	// repositoryName according to policy.
@@ -125,30 +167,74 @@ type EntryCataloger interface {
 	// expired objects.  It also removes the "deleting" mark from those objects that have an
 	// entry _not_ marked as expiring and therefore were not on the returned rows.
 	DeleteOrUnmarkObjectsForDeletion(ctx context.Context, repositoryName string) (StringRows, error)
+	// ListGarbageCandidates returns the physical addresses of objects stored before the given time that are no
+	// longer referenced by any entry, on any branch or commit.  Returned objects are no longer deduplicated, so
+	// each is returned once.
+	ListGarbageCandidates(ctx context.Context, repository string, before time.Time) ([]string, error)
 
 	DedupReportChannel() chan *DedupReport
 }
 
+type CreateMultipartUploadParams struct {
+	// Metadata and ContentType are set on the entry created when the upload completes
+	Metadata    Metadata
+	ContentType string
+}
+
 type MultipartUpdateCataloger interface {
-	CreateMultipartUpload(ctx context.Context, repository, uploadID, path, physicalAddress string, creationTime time.Time) error
+	CreateMultipartUpload(ctx context.Context, repository, uploadID, path, physicalAddress string, creationTime time.Time, params CreateMultipartUploadParams) error
 	GetMultipartUpload(ctx context.Context, repository, uploadID string) (*MultipartUpload, error)
+	// DeleteMultipartUpload aborts an upload, removing it with all its parts
 	DeleteMultipartUpload(ctx context.Context, repository, uploadID string) error
+	// PutMultipartUploadPart records an uploaded part, replacing a part previously uploaded with the same number
+	PutMultipartUploadPart(ctx context.Context, repository, uploadID string, part MultipartUploadPart) error
+	ListMultipartUploadParts(ctx context.Context, repository, uploadID string) ([]*MultipartUploadPart, error)
+	// CompleteMultipartUpload creates an uncommitted entry on branch for the upload assembled from parts, with an
+	// S3 multipart ETag as its checksum, and removes the upload.
+	CompleteMultipartUpload(ctx context.Context, repository, branch, uploadID string, parts []MultipartUploadPart) (*Entry, error)
 }
 
 type Committer interface {
 	Commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error)
+	CommitIfHead(ctx context.Context, repository, branch string, expectedReference string, message string, committer string, metadata Metadata) (*CommitLog, error)
 	GetCommit(ctx context.Context, repository, reference string) (*CommitLog, error)
+	// ListCommits lists the commits reachable from branch, newest first, including commits merged from other
+	// branches.  Pass the last reference of a page as fromReference to list the next page.
 	ListCommits(ctx context.Context, repository, branch string, fromReference string, limit int) ([]*CommitLog, bool, error)
 	RollbackCommit(ctx context.Context, repository, reference string) error
+	// ResolveRef returns the commit reference of a branch, tag or commit reference, in that precedence.
+	// A "~N" suffix walks back N commits.
+	ResolveRef(ctx context.Context, repository, ref string) (string, error)
+	// LastModifiedCommit returns the commit that last changed the object at path, walking the first parents of
+	// ref.  Returns ErrEntryNotFound if there is no object at path in ref.
+	LastModifiedCommit(ctx context.Context, repository, ref, path string) (*CommitLog, error)
 }
 
+// Differ lists differences sorted by path. Paged calls return the differences that follow the path passed as after.
 type Differ interface {
 	Diff(ctx context.Context, repository, leftBranch string, rightBranch string, limit int, after string) (Differences, bool, error)
-	DiffUncommitted(ctx context.Context, repository, branch string, limit int, after string) (Differences, bool, error)
+	DiffCommits(ctx context.Context, repository, leftReference, rightReference string, limit int, after string) (Differences, bool, error)
+	// DiffRefs lists the changes leftRef introduces relative to its merge base with rightRef
+	DiffRefs(ctx context.Context, repository, leftRef, rightRef string, limit int, after string) (Differences, bool, error)
+	DiffUncommitted(ctx context.Context, repository, branch string, prefix string, limit int, after string) (Differences, bool, error)
+	// DiffUncommittedStream calls fn with each uncommitted change as it is read, stopping at the first error fn returns
+	DiffUncommittedStream(ctx context.Context, repository, branch string, prefix string, fn func(Difference) error) error
+	DiffUncommittedSummary(ctx context.Context, repository, branch string) (map[DifferenceType]int, error)
+}
+
+// TagCataloger manages immutable names for commits.  A tag name can be used wherever a reference is accepted,
+// a branch with the same name takes precedence.
+type TagCataloger interface {
+	CreateTag(ctx context.Context, repository, tagName, commitID string) error
+	GetTag(ctx context.Context, repository, tagName string) (*CommitLog, error)
+	DeleteTag(ctx context.Context, repository, tagName string) error
 }
 
 type Merger interface {
 	Merge(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata Metadata) (*MergeResult, error)
+	// CherryPick applies the changes reference introduced over its first parent as uncommitted changes on
+	// destBranch, reporting paths destBranch changed otherwise as conflicts.
+	CherryPick(ctx context.Context, repository, reference, destBranch string) (*CherryPickResult, error)
 }
 
 type Cataloger interface {
@@ -159,6 +245,7 @@ type Cataloger interface {
 	MultipartUpdateCataloger
 	Differ
 	Merger
+	TagCataloger
 	io.Closer
 }
 
@@ -188,6 +275,9 @@ type cataloger struct {
 	dedupReportEnabled   bool
 	dedupReportCh        chan *DedupReport
 	readEntryRequestChan chan *readRequest
+	metrics              MetricsRecorder
+	commitHooks          []commitHook
+	preCommitHooks       []PreCommitHookFunc
 }
 
 type CatalogerOption func(*cataloger)
@@ -204,6 +294,32 @@ func WithDedupReportChannel(b bool) CatalogerOption {
 	}
 }
 
+// WithMetricsRecorder sets the recorder notified of completed diffs, which are not recorded by default
+func WithMetricsRecorder(r MetricsRecorder) CatalogerOption {
+	return func(c *cataloger) {
+		c.metrics = r
+	}
+}
+
+// WithCommitHook adds hook to the hooks called with every successful commit, in the order they were added.
+// Unless failCommit is set, hooks are called after the commit is stored and their errors are only logged.
+// With failCommit set, the hook is called before the commit transaction ends and an error fails the commit,
+// the hook may be called more than once if the transaction is retried.
+func WithCommitHook(hook CommitHookFunc, failCommit bool) CatalogerOption {
+	return func(c *cataloger) {
+		c.commitHooks = append(c.commitHooks, commitHook{fn: hook, failCommit: failCommit})
+	}
+}
+
+// WithPreCommitHook adds hook to the hooks called before every commit is stored, in the order they were added.
+// A hook error fails the commit, leaving the branch unchanged.  Hooks are called inside the commit transaction and
+// may be called more than once if the transaction is retried.
+func WithPreCommitHook(hook PreCommitHookFunc) CatalogerOption {
+	return func(c *cataloger) {
+		c.preCommitHooks = append(c.preCommitHooks, hook)
+	}
+}
+
 func WithParams(p params.Catalog) CatalogerOption {
 	return func(c *cataloger) {
 		if p.BatchRead.ScanTimeout != 0 {
@@ -227,6 +343,9 @@ func WithParams(p params.Catalog) CatalogerOption {
 		if p.Cache.Size != 0 {
 			c.Cache.Size = p.Cache.Size
 		}
+		if p.Cache.EntriesSize != 0 {
+			c.Cache.EntriesSize = p.Cache.EntriesSize
+		}
 		if p.Cache.Expiry != 0 {
 			c.Cache.Expiry = p.Cache.Expiry
 		}
@@ -234,6 +353,13 @@ func WithParams(p params.Catalog) CatalogerOption {
 			c.Cache.Jitter = p.Cache.Jitter
 		}
 		c.Cache.Enabled = p.Cache.Enabled
+		if p.Repository.DeleteRetention != 0 {
+			c.Repository.DeleteRetention = p.Repository.DeleteRetention
+		}
+		if p.Path.MaxLength != 0 {
+			c.Path.MaxLength = p.Path.MaxLength
+		}
+		c.Path.RejectControlCharacters = p.Path.RejectControlCharacters
 	}
 }
 
@@ -243,6 +369,7 @@ func NewCataloger(db db.Database, options ...CatalogerOption) Cataloger {
 		db:                 db,
 		dedupCh:            make(chan *dedupRequest, dedupChannelSize),
 		dedupReportEnabled: true,
+		metrics:            nopMetricsRecorder{},
 		Catalog: params.Catalog{
 			BatchRead: params.BatchRead{
 				EntryMaxWait:  defaultBatchReadEntryMaxWait,
@@ -260,13 +387,19 @@ func NewCataloger(db db.Database, options ...CatalogerOption) Cataloger {
 				Expiry:  defaultCatalogerCacheExpiry,
 				Jitter:  defaultCatalogerCacheJitter,
 			},
+			Repository: params.Repository{
+				DeleteRetention: defaultRepositoryDeleteRetention,
+			},
+			Path: params.Path{
+				MaxLength: DefaultMaxPathLength,
+			},
 		},
 	}
 	for _, opt := range options {
 		opt(c)
 	}
 	if c.Cache.Enabled {
-		c.cache = NewLRUCache(c.Cache.Size, c.Cache.Expiry, c.Cache.Jitter)
+		c.cache = NewLRUCache(c.Cache.Size, c.Cache.EntriesSize, c.Cache.Expiry, c.Cache.Jitter)
 	} else {
 		c.cache = &DummyCache{}
 	}
@@ -39,6 +39,9 @@ func (c *cataloger) getRepositoryIDCache(tx db.Tx, repository string) (int, erro
 func (c *cataloger) getBranchIDCache(tx db.Tx, repository string, branch string) (int64, error) {
 	branchID, err := c.cache.BranchID(repository, branch, func(repository string, branch string) (int64, error) {
 		branchID, err := getBranchID(tx, repository, branch, LockTypeNone)
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, ErrBranchNotFound
+		}
 		if err != nil {
 			return 0, fmt.Errorf("get branch id: %w", err)
 		}
@@ -49,3 +52,16 @@ func (c *cataloger) getBranchIDCache(tx db.Tx, repository string, branch string)
 	}
 	return branchID, err
 }
+
+// getCommittedEntryCache reads the entry of path as of ref using readFn, through the cache when ref references a
+// specific commit
+func (c *cataloger) getCommittedEntryCache(repository string, ref Ref, path string, readFn GetCommittedEntryFn) (*Entry, error) {
+	if ref.CommitID <= UncommittedID {
+		return readFn(repository, ref, path)
+	}
+	entry, err := c.cache.CommittedEntry(repository, ref, path, readFn)
+	if errors.Is(err, cache.ErrCacheItemNotFound) {
+		return entry, db.ErrNotFound
+	}
+	return entry, err
+}
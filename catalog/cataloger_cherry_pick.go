@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// CherryPickResult lists the paths changed by a cherry-picked commit
+type CherryPickResult struct {
+	// Applied lists the paths whose change was written to the destination branch, or was already there
+	Applied []string
+	// Conflicts lists the paths the destination branch changed otherwise, which are left as they are
+	Conflicts []string
+}
+
+type cherryPickChange struct {
+	Path          string `db:"path"`
+	Removed       bool   `db:"removed"`
+	DestUnchanged bool   `db:"dest_unchanged"`
+	DestApplied   bool   `db:"dest_applied"`
+}
+
+// CherryPick applies the changes reference introduced over its first parent, as found by DiffCommits, as
+// uncommitted changes on destBranch.  A path that destBranch already changed differently from the parent is
+// reported as a conflict and left as it is, all other changes are applied in the same transaction.
+// reference is resolved by ResolveRef.
+func (c *cataloger) CherryPick(ctx context.Context, repository, reference, destBranch string) (*CherryPickResult, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "destBranch", Check: ValidateBranchName(destBranch)},
+	}); err != nil {
+		return nil, err
+	}
+	commitReference, err := c.ResolveRef(ctx, repository, reference)
+	if err != nil {
+		return nil, fmt.Errorf("reference: %w", err)
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		commit, err := c.getRefCommit(tx, repository, commitReference)
+		if err != nil {
+			return nil, fmt.Errorf("reference: %w", err)
+		}
+		parentBranchID, parentCommitID, err := getCommitFirstParent(tx, commit.BranchID, commit.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf("parent commit: %w", err)
+		}
+		destID, err := c.getBranchIDCache(tx, repository, destBranch)
+		if err != nil {
+			return nil, fmt.Errorf("destination branch: %w", err)
+		}
+
+		changes, err := getCherryPickChanges(tx, commit, lineageCommit{BranchID: parentBranchID, CommitID: parentCommitID}, destID)
+		if err != nil {
+			return nil, err
+		}
+		result := &CherryPickResult{}
+		var writePaths, removePaths []string
+		for _, change := range changes {
+			switch {
+			case change.DestApplied:
+				result.Applied = append(result.Applied, change.Path)
+			case !change.DestUnchanged:
+				result.Conflicts = append(result.Conflicts, change.Path)
+			case change.Removed:
+				result.Applied = append(result.Applied, change.Path)
+				removePaths = append(removePaths, change.Path)
+			default:
+				result.Applied = append(result.Applied, change.Path)
+				writePaths = append(writePaths, change.Path)
+			}
+		}
+		if err := c.cherryPickWrite(tx, commit, destID, writePaths); err != nil {
+			return nil, err
+		}
+		if err := cherryPickRemove(tx, destID, removePaths); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*CherryPickResult), nil
+}
+
+// getCherryPickChanges returns the paths commit changed over parent, in path order, with whether the uncommitted
+// state of destID still matches parent or already matches commit
+func getCherryPickChanges(tx db.Tx, commit, parent lineageCommit, destID int64) ([]cherryPickChange, error) {
+	commitQ, err := sqDiffCommitEntries(tx, commit.BranchID, commit.CommitID)
+	if err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	parentQ, err := sqDiffCommitEntries(tx, parent.BranchID, parent.CommitID)
+	if err != nil {
+		return nil, fmt.Errorf("parent commit: %w", err)
+	}
+	destQ, err := sqDiffCommitEntries(tx, destID, UncommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("destination branch: %w", err)
+	}
+	query, args, err := psql.Select("COALESCE(c.path, p.path) AS path", "c.path IS NULL AS removed",
+		"CASE WHEN d.path IS NULL THEN p.path IS NULL ELSE p.path IS NOT NULL AND "+sqSameContent("d", "p")+" AND d.metadata IS NOT DISTINCT FROM p.metadata END AS dest_unchanged",
+		"CASE WHEN d.path IS NULL THEN c.path IS NULL ELSE c.path IS NOT NULL AND "+sqSameContent("d", "c")+" AND d.metadata IS NOT DISTINCT FROM c.metadata END AS dest_applied").
+		FromSelect(commitQ, "c").
+		JoinClause(parentQ.Prefix("FULL OUTER JOIN (").Suffix(") AS p ON c.path=p.path")).
+		JoinClause(destQ.Prefix("LEFT JOIN (").Suffix(") AS d ON d.path=COALESCE(c.path, p.path)")).
+		Where("c.path IS NULL OR p.path IS NULL OR NOT " + sqSameContent("c", "p") + " OR c.metadata IS DISTINCT FROM p.metadata").
+		OrderBy("path").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+	var changes []cherryPickChange
+	if err := tx.Select(&changes, query, args...); err != nil {
+		return nil, fmt.Errorf("select changes: %w", err)
+	}
+	return changes, nil
+}
+
+// cherryPickWrite copies the entries of commit at paths to destID as uncommitted entries
+func (c *cataloger) cherryPickWrite(tx db.Tx, commit lineageCommit, destID int64, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	lineage, err := getLineage(tx, commit.BranchID, commit.CommitID)
+	if err != nil {
+		return fmt.Errorf("get lineage: %w", err)
+	}
+	query, args, err := psql.Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "checksums", "content_type", "expires_at", "is_expired").
+		FromSelect(sqEntriesLineage(commit.BranchID, commit.CommitID, lineage), "entries").
+		Where(sq.Eq{"path": paths}).
+		OrderBy("path").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build sql: %w", err)
+	}
+	var entries []*Entry
+	if err := tx.Select(&entries, query, args...); err != nil {
+		return fmt.Errorf("select entries: %w", err)
+	}
+	return c.insertEntries(tx, destID, entries)
+}
+
+// cherryPickRemove deletes paths from destID like DeleteEntry: uncommitted entries are removed and committed
+// entries hidden by a tombstone
+func cherryPickRemove(tx db.Tx, destID int64, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	query, args, err := psql.Delete("catalog_entries").
+		Where(sq.Eq{"branch_id": destID, "min_commit": 0, "path": paths}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build sql: %w", err)
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("uncommitted: %w", err)
+	}
+
+	lineage, err := getLineage(tx, destID, CommittedID)
+	if err != nil {
+		return fmt.Errorf("get lineage: %w", err)
+	}
+	tombstones := sq.Select(strconv.FormatInt(destID, 10), "path", "''", "''", "0", "'{}'", "0", "0").
+		FromSelect(sqEntriesLineage(destID, CommittedID, lineage), "entries").
+		Where(sq.And{sq.Eq{"path": paths}, sq.Expr("NOT is_deleted")})
+	query, args, err = psql.Insert("catalog_entries").
+		Columns("branch_id", "path", "physical_address", "checksum", "size", "metadata", "min_commit", "max_commit").
+		Select(tombstones).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build sql: %w", err)
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("tombstones: %w", err)
+	}
+	return nil
+}
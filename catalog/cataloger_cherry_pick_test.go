@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CherryPick(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first", "tester", nil)
+	testutil.MustDo(t, "commit on master", err)
+
+	testCatalogerBranch(t, ctx, c, repository, "feature", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature", "file1", nil, "feature")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "feature", "file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature", "file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature", "file4", nil, "feature")
+	_, err = c.Commit(ctx, repository, "feature", "changes", "tester", nil)
+	testutil.MustDo(t, "commit on feature", err)
+
+	// master changed file4 on its own
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file4", nil, "master")
+
+	res, err := c.CherryPick(ctx, repository, "feature", "master")
+	testutil.MustDo(t, "cherry pick", err)
+	expected := &CherryPickResult{
+		Applied:   []string{"file1", "file2", "file3"},
+		Conflicts: []string{"file4"},
+	}
+	if diff := deep.Equal(res, expected); diff != nil {
+		t.Fatal("CherryPick()", diff)
+	}
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "master"), []string{"file1", "file3", "file4"}); diff != nil {
+		t.Fatal("CherryPick() entries", diff)
+	}
+	for path, seed := range map[string]string{"file1": "feature", "file4": "master"} {
+		entry, err := c.GetEntry(ctx, repository, "master", path, GetEntryParams{})
+		testutil.MustDo(t, "get entry", err)
+		if expected := testCreateEntryCalcChecksum(path, seed); entry.Checksum != expected {
+			t.Errorf("CherryPick() %s checksum %s, expected %s", path, entry.Checksum, expected)
+		}
+	}
+
+	// picking again finds the changes already applied
+	res, err = c.CherryPick(ctx, repository, "feature", "master")
+	testutil.MustDo(t, "cherry pick again", err)
+	if diff := deep.Equal(res, expected); diff != nil {
+		t.Fatal("CherryPick() again", diff)
+	}
+}
+
+func TestCataloger_CherryPick_UnknownReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	if _, err := c.CherryPick(ctx, repository, "no-branch", "master"); err == nil {
+		t.Fatal("CherryPick() of unknown reference expected to fail")
+	}
+}
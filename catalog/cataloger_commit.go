@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,8 +11,33 @@ import (
 )
 
 func (c *cataloger) Commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error) {
+	return c.commit(ctx, repository, branch, message, committer, metadata, UncommittedID)
+}
+
+// CommitIfHead commits like Commit, only if expectedReference is still the last commit of branch.
+// Otherwise, the branch was committed or merged into since, and ErrBranchModified is returned.
+func (c *cataloger) CommitIfHead(ctx context.Context, repository, branch string, expectedReference string, message string, committer string, metadata Metadata) (*CommitLog, error) {
 	if err := Validate(ValidateFields{
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "expectedReference", IsValid: ValidateReference(expectedReference)},
+	}); err != nil {
+		return nil, err
+	}
+	ref, err := ParseRef(expectedReference)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Branch != branch || ref.CommitID <= UncommittedID {
+		return nil, fmt.Errorf("%w: expected a commit of branch %s", ErrInvalidReference, branch)
+	}
+	return c.commit(ctx, repository, branch, message, committer, metadata, ref.CommitID)
+}
+
+// commit uncommitted entries of branch. Unless expectedCommitID is UncommittedID, it must match the last commit
+// of the branch.
+func (c *cataloger) commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata, expectedCommitID CommitID) (*CommitLog, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 		{Name: "message", IsValid: ValidateCommitMessage(message)},
 		{Name: "committer", IsValid: ValidateCommitter(committer)},
 	}); err != nil {
@@ -19,7 +45,11 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 	}
 
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// exclusive lock - concurrent commits on the branch are serialized
 		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrBranchNotFound
+		}
 		if err != nil {
 			return nil, fmt.Errorf("get branch id: %w", err)
 		}
@@ -28,58 +58,103 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 		if err != nil {
 			return nil, fmt.Errorf("last commit id: %w", err)
 		}
-
-		committedAffected, err := commitUpdateCommittedEntriesWithMaxCommit(tx, branchID, lastCommitID)
-		if err != nil {
-			return nil, fmt.Errorf("update commit entries: %w", err)
+		if expectedCommitID != UncommittedID && lastCommitID != expectedCommitID {
+			return nil, ErrBranchModified
 		}
 
-		_, err = commitDeleteUncommittedTombstones(tx, branchID, lastCommitID)
-		if err != nil {
-			return nil, fmt.Errorf("delete uncommitted tombstones: %w", err)
+		event := &CommitEvent{Repository: repository, Branch: branch}
+		if c.hasCommitHooks() {
+			event.Summary, err = getUncommittedSummary(tx, branchID)
+			if err != nil {
+				return nil, fmt.Errorf("uncommitted summary: %w", err)
+			}
 		}
-
-		// uncommitted to committed entries
-		commitID, err := getNextCommitID(tx)
-		if err != nil {
-			return nil, fmt.Errorf("next commit id: %w", err)
+		if err := c.runPreCommitHooks(ctx, PreCommitEvent{
+			Repository: repository,
+			Branch:     branch,
+			Message:    message,
+			Committer:  committer,
+			Metadata:   metadata,
+			Summary:    event.Summary,
+			Changes: func(limit int, after string) (Differences, bool, error) {
+				if limit < 0 || limit > DiffMaxLimit {
+					limit = DiffMaxLimit
+				}
+				differences, err := getUncommittedDifferences(tx, branchID, "", limit+1, after)
+				if err != nil {
+					return nil, false, err
+				}
+				hasMore := paginateSlice(&differences, limit)
+				return differences, hasMore, nil
+			},
+		}); err != nil {
+			return nil, err
 		}
-
-		// commit entries (include the tombstones)
-		affectedNew, err := commitEntries(tx, branchID, commitID)
+		event.CommitLog, err = commitBranch(tx, branch, branchID, lastCommitID, message, committer, metadata)
 		if err != nil {
-			return nil, fmt.Errorf("commit entries: %w", err)
-		}
-		if (affectedNew + committedAffected) == 0 {
-			return nil, ErrNothingToCommit
-		}
-
-		// insert commit record
-		var creationDate time.Time
-		if err = tx.Get(&creationDate,
-			`INSERT INTO catalog_commits (branch_id,commit_id,committer,message,creation_date,metadata,merge_type,previous_commit_id)
-			VALUES ($1,$2,$3,$4,transaction_timestamp(),$5,$6,$7)
-			RETURNING creation_date`,
-			branchID, commitID, committer, message, metadata, RelationTypeNone, lastCommitID,
-		); err != nil {
 			return nil, err
 		}
-		reference := MakeReference(branch, commitID)
-		parentReference := MakeReference(branch, lastCommitID)
-		commitLog := &CommitLog{
-			Committer:    committer,
-			Message:      message,
-			CreationDate: creationDate,
-			Metadata:     metadata,
-			Reference:    reference,
-			Parents:      []string{parentReference},
+		if err := c.runCommitHooks(ctx, *event, true); err != nil {
+			return nil, err
 		}
-		return commitLog, nil
+		return event, nil
 	}, c.txOpts(ctx)...)
 	if err != nil {
 		return nil, err
 	}
-	return res.(*CommitLog), nil
+	event := res.(*CommitEvent)
+	_ = c.runCommitHooks(ctx, *event, false)
+	return event.CommitLog, nil
+}
+
+// commitBranch commits the uncommitted entries of branch on top of its last commit lastCommitID
+func commitBranch(tx db.Tx, branch string, branchID int64, lastCommitID CommitID, message string, committer string, metadata Metadata) (*CommitLog, error) {
+	committedAffected, err := commitUpdateCommittedEntriesWithMaxCommit(tx, branchID, lastCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("update commit entries: %w", err)
+	}
+
+	_, err = commitDeleteUncommittedTombstones(tx, branchID, lastCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("delete uncommitted tombstones: %w", err)
+	}
+
+	// uncommitted to committed entries
+	commitID, err := getNextCommitID(tx)
+	if err != nil {
+		return nil, fmt.Errorf("next commit id: %w", err)
+	}
+
+	// commit entries (include the tombstones)
+	affectedNew, err := commitEntries(tx, branchID, commitID)
+	if err != nil {
+		return nil, fmt.Errorf("commit entries: %w", err)
+	}
+	if (affectedNew + committedAffected) == 0 {
+		return nil, ErrNothingToCommit
+	}
+
+	// insert commit record
+	var creationDate time.Time
+	if err = tx.Get(&creationDate,
+		`INSERT INTO catalog_commits (branch_id,commit_id,committer,message,creation_date,metadata,merge_type,previous_commit_id)
+		VALUES ($1,$2,$3,$4,transaction_timestamp(),$5,$6,$7)
+		RETURNING creation_date`,
+		branchID, commitID, committer, message, metadata, RelationTypeNone, lastCommitID,
+	); err != nil {
+		return nil, err
+	}
+	reference := MakeReference(branch, commitID)
+	parentReference := MakeReference(branch, lastCommitID)
+	commitLog := &CommitLog{
+		Committer:    committer,
+		Message:      message,
+		CreationDate: creationDate,
+		Metadata:     metadata,
+		Reference:    reference,
+		Parents:      []string{parentReference},
+	}
+	return commitLog, nil
 }
 
 func commitUpdateCommittedEntriesWithMaxCommit(tx sqlx.Execer, branchID int64, commitID CommitID) (int64, error) {
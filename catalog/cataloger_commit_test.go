@@ -76,6 +76,12 @@ func TestCataloger_Commit(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "invalid repository",
+			args:    args{repository: "", branch: "master", message: "commit message", committer: "tester", metadata: meta},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "no branch",
 			args:    args{repository: repository, branch: "shifu", message: "commit message", committer: "tester", metadata: meta},
@@ -288,3 +294,220 @@ func TestCataloger_CommitTombstoneShouldNotChangeHistory(t *testing.T) {
 		t.Fatalf("get entry from branch commit checksum=%s, expected, %s", ent.Checksum, checksumFile42)
 	}
 }
+
+func TestCataloger_Commit_MissingBranch(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	_, err := c.Commit(ctx, repository, "nobranch", "commit message", "tester", nil)
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Fatalf("Commit() err = %v, expected %s", err, ErrBranchNotFound)
+	}
+}
+
+func TestCataloger_Commit_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+
+	// only one of the concurrent commits has something to commit
+	const workers = 5
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			_, err := c.Commit(ctx, repository, "master", "commit "+strconv.Itoa(i), "tester", nil)
+			errs <- err
+		}(i)
+	}
+	var committed int
+	for i := 0; i < workers; i++ {
+		err := <-errs
+		switch {
+		case err == nil:
+			committed++
+		case !errors.Is(err, ErrNothingToCommit):
+			t.Errorf("Commit() unexpected error: %s", err)
+		}
+	}
+	if committed != 1 {
+		t.Fatalf("Commit() succeeded %d times, expected exactly one", committed)
+	}
+	commits, _, err := c.ListCommits(ctx, repository, "master", "", -1)
+	testutil.MustDo(t, "list commits", err)
+	// the commit and the initial repository commit
+	const expectedCommits = 2
+	if len(commits) != expectedCommits {
+		t.Fatalf("ListCommits() got %d commits, expected %d", len(commits), expectedCommits)
+	}
+}
+
+func TestCataloger_CommitIfHead(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	head, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get branch reference", err)
+
+	// two clients start from the same head, the first to commit wins
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.CommitIfHead(ctx, repository, "master", head, "first commit", "tester", nil)
+	testutil.MustDo(t, "commit if head", err)
+	if commitLog.Parents[0] != head {
+		t.Fatalf("CommitIfHead() parent = %s, expected %s", commitLog.Parents[0], head)
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+	_, err = c.CommitIfHead(ctx, repository, "master", head, "second commit", "tester", nil)
+	if !errors.Is(err, ErrBranchModified) {
+		t.Fatalf("CommitIfHead() with stale reference err = %v, expected %s", err, ErrBranchModified)
+	}
+
+	// retry with the current head
+	_, err = c.CommitIfHead(ctx, repository, "master", commitLog.Reference, "second commit", "tester", nil)
+	testutil.MustDo(t, "commit if head with current reference", err)
+
+	// reference must be a commit of the same branch
+	testCatalogerBranch(t, ctx, c, repository, "b1", "master")
+	b1Head, err := c.GetBranchReference(ctx, repository, "b1")
+	testutil.MustDo(t, "get branch reference", err)
+	for _, ref := range []string{"master", b1Head} {
+		_, err = c.CommitIfHead(ctx, repository, "master", ref, "commit", "tester", nil)
+		if !errors.Is(err, ErrInvalidReference) {
+			t.Fatalf("CommitIfHead() with reference %s err = %v, expected %s", ref, err, ErrInvalidReference)
+		}
+	}
+}
+
+func TestCataloger_Commit_Hooks(t *testing.T) {
+	ctx := context.Background()
+	var events []CommitEvent
+	errHook := errors.New("hook failed")
+	var failHook, failCommitHook bool
+	c := testCataloger(t,
+		WithCommitHook(func(_ context.Context, event CommitEvent) error {
+			events = append(events, event)
+			return nil
+		}, false),
+		WithCommitHook(func(context.Context, CommitEvent) error {
+			if failHook {
+				return errHook
+			}
+			return nil
+		}, false),
+		WithCommitHook(func(context.Context, CommitEvent) error {
+			if failCommitHook {
+				return errHook
+			}
+			return nil
+		}, true),
+	)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "add files", "tester", nil)
+	testutil.MustDo(t, "commit add files", err)
+	if len(events) != 1 {
+		t.Fatalf("Commit() hook called %d times, expected 1", len(events))
+	}
+	event := events[0]
+	if event.Repository != repository || event.Branch != "master" {
+		t.Errorf("Commit() hook event on %s/%s, expected %s/master", event.Repository, event.Branch, repository)
+	}
+	if !reflect.DeepEqual(event.CommitLog, commitLog) {
+		t.Errorf("Commit() hook event commit %s, expected %s", spew.Sdump(event.CommitLog), spew.Sdump(commitLog))
+	}
+	if expected := map[DifferenceType]int{DifferenceTypeAdded: 2}; !reflect.DeepEqual(event.Summary, expected) {
+		t.Errorf("Commit() hook event summary %v, expected %v", event.Summary, expected)
+	}
+
+	// hook errors are ignored unless the hook fails the commit
+	failHook = true
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "changed")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "file2"))
+	_, err = c.Commit(ctx, repository, "master", "change files", "tester", nil)
+	testutil.MustDo(t, "commit with failing hook", err)
+	if len(events) != 2 {
+		t.Fatalf("Commit() hook called %d times, expected 2", len(events))
+	}
+	if expected := map[DifferenceType]int{DifferenceTypeChanged: 1, DifferenceTypeRemoved: 1}; !reflect.DeepEqual(events[1].Summary, expected) {
+		t.Errorf("Commit() hook event summary %v, expected %v", events[1].Summary, expected)
+	}
+
+	failCommitHook = true
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file3", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "add file3", "tester", nil)
+	if !errors.Is(err, errHook) {
+		t.Fatalf("Commit() with hook failing the commit err = %v, expected %s", err, errHook)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Commit() hook called %d times on failed commit, expected 2", len(events))
+	}
+	hasChanges, err := c.HasUncommittedChanges(ctx, repository, "master")
+	testutil.MustDo(t, "has uncommitted changes", err)
+	if !hasChanges {
+		t.Error("Commit() failed by hook committed the uncommitted changes")
+	}
+}
+
+func TestCataloger_Commit_PreCommitHooks(t *testing.T) {
+	ctx := context.Background()
+	errShortMessage := errors.New("commit message too short")
+	var events []PreCommitEvent
+	var changes []Differences
+	c := testCataloger(t, WithPreCommitHook(func(_ context.Context, event PreCommitEvent) error {
+		differences, _, err := event.Changes(-1, "")
+		if err != nil {
+			return err
+		}
+		changes = append(changes, differences)
+		event.Changes = nil
+		events = append(events, event)
+		if len(event.Message) <= 10 {
+			return errShortMessage
+		}
+		return nil
+	}))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	head, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get branch reference", err)
+
+	// vetoed commit leaves the branch untouched
+	_, err = c.Commit(ctx, repository, "master", "short", "tester", nil)
+	if !errors.Is(err, errShortMessage) {
+		t.Fatalf("Commit() vetoed by hook err = %v, expected %s", err, errShortMessage)
+	}
+	currentHead, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get branch reference after veto", err)
+	if currentHead != head {
+		t.Fatalf("Commit() vetoed by hook moved branch to %s, expected %s", currentHead, head)
+	}
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted after veto", err)
+	if len(differences) != 1 || differences[0].Path != "file1" || differences[0].Type != DifferenceTypeAdded {
+		t.Fatalf("Commit() vetoed by hook left uncommitted changes %s, expected file1 added", spew.Sdump(differences))
+	}
+
+	_, err = c.Commit(ctx, repository, "master", "a long enough message", "tester", Metadata{"k": "v"})
+	testutil.MustDo(t, "commit", err)
+	if len(events) != 2 {
+		t.Fatalf("Commit() pre-commit hook called %d times, expected 2", len(events))
+	}
+	expected := PreCommitEvent{
+		Repository: repository,
+		Branch:     "master",
+		Message:    "a long enough message",
+		Committer:  "tester",
+		Metadata:   Metadata{"k": "v"},
+		Summary:    map[DifferenceType]int{DifferenceTypeAdded: 1},
+	}
+	if !reflect.DeepEqual(events[1], expected) {
+		t.Errorf("Commit() pre-commit hook event %s, expected %s", spew.Sdump(events[1]), spew.Sdump(expected))
+	}
+	if len(changes[1]) != 1 || changes[1][0].Path != "file1" || changes[1][0].Type != DifferenceTypeAdded || changes[1][0].Size == 0 {
+		t.Errorf("Commit() pre-commit hook changes %s, expected file1 added", spew.Sdump(changes[1]))
+	}
+}
@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/db"
+)
+
+// CompleteMultipartUpload assembles the upload from parts, which must be in ascending part number order and match
+// uploaded parts by ETag.  Uploaded parts that are not listed are dropped.  The entry checksum follows the S3
+// multipart ETag, see block.ComputeMultipartETag.
+func (c *cataloger) CompleteMultipartUpload(ctx context.Context, repository, branch, uploadID string, parts []MultipartUploadPart) (*Entry, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+		{Name: "uploadID", IsValid: ValidateUploadID(uploadID)},
+	}); err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("no parts: %w", ErrInvalidMultipartUploadPart)
+	}
+
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		upload, err := c.getMultipartUpload(tx, repository, uploadID, LockTypeUpdate)
+		if err != nil {
+			return nil, err
+		}
+		uploadedParts, err := getMultipartUploadParts(tx, uploadID)
+		if err != nil {
+			return nil, fmt.Errorf("get parts: %w", err)
+		}
+		uploaded := make(map[int]*MultipartUploadPart, len(uploadedParts))
+		for _, part := range uploadedParts {
+			uploaded[part.PartNumber] = part
+		}
+
+		entry := Entry{
+			Path:            upload.Path,
+			PhysicalAddress: upload.PhysicalAddress,
+			Metadata:        upload.Metadata,
+			ContentType:     upload.ContentType,
+		}
+		partETags := make([]string, len(parts))
+		for i, part := range parts {
+			if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+				return nil, fmt.Errorf("part %d out of order: %w", part.PartNumber, ErrInvalidMultipartUploadPart)
+			}
+			uploadedPart, ok := uploaded[part.PartNumber]
+			if !ok || trimETag(uploadedPart.ETag) != trimETag(part.ETag) {
+				return nil, fmt.Errorf("part %d: %w", part.PartNumber, ErrInvalidMultipartUploadPart)
+			}
+			partETags[i] = uploadedPart.ETag
+			entry.Size += uploadedPart.Size
+		}
+		entry.Checksum, err = block.ComputeMultipartETagFromETags(partETags)
+		if err != nil {
+			return nil, joinErrors(fmt.Errorf("parts etag: %w", err), ErrInvalidMultipartUploadPart)
+		}
+
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := lockEntryPath(tx, branchID, entry.Path); err != nil {
+			return nil, err
+		}
+		if _, err := insertEntry(tx, branchID, &entry); err != nil {
+			return nil, err
+		}
+		// parts are removed with their upload
+		if _, err := tx.Exec(`DELETE FROM catalog_multipart_uploads WHERE upload_id = $1`, uploadID); err != nil {
+			return nil, fmt.Errorf("delete upload: %w", err)
+		}
+		return &entry, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*Entry), nil
+}
+
+func trimETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CompleteMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create multipart upload",
+		c.CreateMultipartUpload(ctx, repository, "upload1", "/path1", "/file1", time.Now(), CreateMultipartUploadParams{
+			Metadata:    Metadata{"key": "value"},
+			ContentType: "text/plain",
+		}))
+	uploadedParts := []MultipartUploadPart{
+		{PartNumber: 1, ETag: "0cc175b9c0f1b6a831c399e269772661", Size: 1, PhysicalAddress: "/file1"},
+		{PartNumber: 2, ETag: "92eb5ffee6ae2fec3ad71c777531578f", Size: 1, PhysicalAddress: "/file1"},
+		{PartNumber: 3, ETag: "4a8a08f09d37b73795649038408b5f33", Size: 1, PhysicalAddress: "/file1"},
+	}
+	for _, part := range uploadedParts {
+		testutil.MustDo(t, "put part", c.PutMultipartUploadPart(ctx, repository, "upload1", part))
+	}
+
+	tests := []struct {
+		name  string
+		parts []MultipartUploadPart
+	}{
+		{name: "no parts", parts: nil},
+		{name: "out of order", parts: []MultipartUploadPart{{PartNumber: 2, ETag: uploadedParts[1].ETag}, {PartNumber: 1, ETag: uploadedParts[0].ETag}}},
+		{name: "unknown part", parts: []MultipartUploadPart{{PartNumber: 4, ETag: uploadedParts[0].ETag}}},
+		{name: "etag mismatch", parts: []MultipartUploadPart{{PartNumber: 1, ETag: uploadedParts[1].ETag}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.CompleteMultipartUpload(ctx, repository, "master", "upload1", tt.parts)
+			if !errors.Is(err, ErrInvalidMultipartUploadPart) {
+				t.Fatalf("CompleteMultipartUpload() err = %v, expected %s", err, ErrInvalidMultipartUploadPart)
+			}
+		})
+	}
+
+	// complete with quoted etags, as sent by S3 clients, leaving out part 3
+	entry, err := c.CompleteMultipartUpload(ctx, repository, "master", "upload1", []MultipartUploadPart{
+		{PartNumber: 1, ETag: `"` + uploadedParts[0].ETag + `"`},
+		{PartNumber: 2, ETag: `"` + uploadedParts[1].ETag + `"`},
+	})
+	testutil.MustDo(t, "complete multipart upload", err)
+	// md5 of the concatenated md5 digests of "a" and "b"
+	const expectedChecksum = "96e024ba2074fe77e8e965ba43a704be-2"
+	if entry.Checksum != expectedChecksum || entry.Size != 2 || entry.Path != "/path1" || entry.PhysicalAddress != "/file1" {
+		t.Fatalf("CompleteMultipartUpload() entry = %+v, expected checksum %s, size 2", entry, expectedChecksum)
+	}
+	ent, err := c.GetEntry(ctx, repository, "master", "/path1", GetEntryParams{})
+	testutil.MustDo(t, "get completed entry", err)
+	if ent.Checksum != expectedChecksum {
+		t.Fatalf("GetEntry() checksum = %s, expected %s", ent.Checksum, expectedChecksum)
+	}
+	if ent.Metadata["key"] != "value" || ent.ContentType != "text/plain" {
+		t.Fatalf("GetEntry() metadata = %v, content type = %s, expected those given on upload create", ent.Metadata, ent.ContentType)
+	}
+	if _, err := c.ListMultipartUploadParts(ctx, repository, "upload1"); !errors.Is(err, ErrMultipartUploadNotFound) {
+		t.Fatalf("ListMultipartUploadParts() after complete err = %v, expected %s", err, ErrMultipartUploadNotFound)
+	}
+}
+
+func TestCataloger_CompleteMultipartUpload_InvalidPartETag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create multipart upload",
+		c.CreateMultipartUpload(ctx, repository, "upload1", "/path1", "/file1", time.Now(), CreateMultipartUploadParams{}))
+	// the etag of a part stored with server side encryption is not its md5
+	part := MultipartUploadPart{PartNumber: 1, ETag: "not-an-md5", Size: 1, PhysicalAddress: "/file1"}
+	testutil.MustDo(t, "put part", c.PutMultipartUploadPart(ctx, repository, "upload1", part))
+
+	_, err := c.CompleteMultipartUpload(ctx, repository, "master", "upload1", []MultipartUploadPart{part})
+	if !errors.Is(err, ErrInvalidMultipartUploadPart) || !errors.Is(err, block.ErrInvalidPartETag) {
+		t.Fatalf("CompleteMultipartUpload() err = %v, expected %s and %s", err, ErrInvalidMultipartUploadPart, block.ErrInvalidPartETag)
+	}
+}
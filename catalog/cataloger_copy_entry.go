@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// CopyEntry creates an uncommitted entry at destPath on destBranch that points to the same object as the entry at
+// srcPath on srcBranch, committed or uncommitted. No data is copied. Returns the new entry, or ErrEntryNotFound if
+// srcPath has no entry on srcBranch.
+func (c *cataloger) CopyEntry(ctx context.Context, repository, srcBranch, srcPath, destBranch, destPath string) (*Entry, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "srcBranch", Check: ValidateBranchName(srcBranch)},
+		{Name: "srcPath", IsValid: ValidatePathLimits(srcPath, c.Path)},
+		{Name: "destBranch", Check: ValidateBranchName(destBranch)},
+		{Name: "destPath", IsValid: ValidatePathLimits(destPath, c.Path)},
+	}); err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		srcBranchID, err := c.getBranchIDCache(tx, repository, srcBranch)
+		if err != nil {
+			return nil, fmt.Errorf("source branch id: %w", err)
+		}
+		destBranchID, err := c.getBranchIDCache(tx, repository, destBranch)
+		if err != nil {
+			return nil, fmt.Errorf("destination branch id: %w", err)
+		}
+		if err := lockEntryPath(tx, destBranchID, destPath); err != nil {
+			return nil, err
+		}
+
+		lineage, err := getLineage(tx, srcBranchID, UncommittedID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		sql, args, err := psql.
+			Select("path", "physical_address", "size", "checksum", "metadata", "checksums", "content_type", "expires_at", "is_expired").
+			FromSelect(sqEntriesLineage(srcBranchID, UncommittedID, lineage), "entries").
+			Where(sq.Eq{"path": srcPath, "is_deleted": false}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		var ent Entry
+		err = tx.Get(&ent, sql, args...)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the underlying object of an expired entry is gone, nothing to point to
+		if ent.Expired {
+			return nil, ErrExpired
+		}
+
+		ent.Path = destPath
+		if _, err := insertEntry(tx, destBranchID, &ent); err != nil {
+			return nil, err
+		}
+		// read back the creation date set by the database
+		if err := tx.Get(&ent.CreationDate, `SELECT creation_date FROM catalog_entries WHERE branch_id=$1 AND path=$2 AND min_commit=0`,
+			destBranchID, destPath); err != nil {
+			return nil, fmt.Errorf("creation date: %w", err)
+		}
+		return &ent, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*Entry), nil
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CopyEntry(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "committed", Metadata{"k": "v"}, "")
+	_, err := c.Commit(ctx, repository, "master", "commit committed", "tester", nil)
+	testutil.MustDo(t, "commit committed", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "uncommitted", nil, "")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	tests := []struct {
+		name       string
+		srcBranch  string
+		srcPath    string
+		destBranch string
+		destPath   string
+		wantErr    error
+	}{
+		{name: "committed", srcBranch: "master", srcPath: "committed", destBranch: "master", destPath: "copy1"},
+		{name: "uncommitted", srcBranch: "master", srcPath: "uncommitted", destBranch: "master", destPath: "copy2"},
+		{name: "across branches", srcBranch: "master", srcPath: "uncommitted", destBranch: "branch1", destPath: "copy3"},
+		{name: "overwrite", srcBranch: "master", srcPath: "uncommitted", destBranch: "master", destPath: "committed"},
+		{name: "missing source", srcBranch: "master", srcPath: "missing", destBranch: "master", destPath: "copy4", wantErr: ErrEntryNotFound},
+		{name: "missing branch", srcBranch: "master", srcPath: "committed", destBranch: "missing", destPath: "copy5", wantErr: db.ErrNotFound},
+		{name: "invalid path", srcBranch: "master", srcPath: "committed", destBranch: "master", destPath: "", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, _ := c.GetEntry(ctx, repository, tt.srcBranch, tt.srcPath, GetEntryParams{})
+			ent, err := c.CopyEntry(ctx, repository, tt.srcBranch, tt.srcPath, tt.destBranch, tt.destPath)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CopyEntry() err = %v, expected %v", err, tt.wantErr)
+			}
+			if err != nil {
+				testCatalogerGetEntry(t, ctx, c, repository, tt.destBranch, tt.destPath, false)
+				return
+			}
+			if ent.Path != tt.destPath {
+				t.Errorf("CopyEntry() path = %s, expected %s", ent.Path, tt.destPath)
+			}
+			dest, err := c.GetEntry(ctx, repository, tt.destBranch, tt.destPath, GetEntryParams{})
+			testutil.MustDo(t, "get copied entry", err)
+			if dest.PhysicalAddress != src.PhysicalAddress || dest.Checksum != src.Checksum || dest.Size != src.Size {
+				t.Errorf("CopyEntry() copied %+v, expected to point to %+v", dest, src)
+			}
+			if len(dest.Metadata) != len(src.Metadata) {
+				t.Errorf("CopyEntry() metadata = %v, expected %v", dest.Metadata, src.Metadata)
+			}
+			if !ent.CreationDate.Equal(dest.CreationDate) {
+				t.Errorf("CopyEntry() creation date = %s, expected %s", ent.CreationDate, dest.CreationDate)
+			}
+		})
+	}
+}
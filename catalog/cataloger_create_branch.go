@@ -12,11 +12,17 @@ const (
 	createBranchCommitMessageFormat = "Branch '%s' created, source branch '%s'"
 )
 
+// CreateBranch creates branch from sourceBranch.  The source may be a branch, a tag or a commit reference, with an
+// optional "~N" ancestry suffix, and is resolved the same way ResolveRef does.
 func (c *cataloger) CreateBranch(ctx context.Context, repository, branch string, sourceBranch string) (*CommitLog, error) {
+	sourceName, sourceGenerations, err := parseRefAncestry(sourceBranch)
+	if err != nil {
+		return nil, err
+	}
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
-		{Name: "sourceBranch", IsValid: ValidateBranchName(sourceBranch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+		{Name: "sourceBranch", IsValid: ValidateReference(sourceName)},
 	}); err != nil {
 		return nil, err
 	}
@@ -32,11 +38,14 @@ func (c *cataloger) CreateBranch(ctx context.Context, repository, branch string,
 			return nil, err
 		}
 
-		// get source branch id and
-		var sourceBranchID int
-		if err := tx.Get(&sourceBranchID, `SELECT id FROM catalog_branches WHERE repository_id=$1 AND name=$2`,
-			repoID, sourceBranch); err != nil {
-			return nil, fmt.Errorf("source branch id: %w", err)
+		// resolve source branch and commit
+		source, err := c.resolveRefAncestryCommit(tx, repository, sourceName, sourceGenerations)
+		if err != nil {
+			return nil, fmt.Errorf("source branch: %w", err)
+		}
+		parentReference, err := getCommitReference(tx, source)
+		if err != nil {
+			return nil, err
 		}
 
 		// next id for branch
@@ -48,35 +57,24 @@ func (c *cataloger) CreateBranch(ctx context.Context, repository, branch string,
 		// insert new branch
 		if _, err := tx.Exec(`INSERT INTO catalog_branches (repository_id, id, name, lineage)
 			VALUES($1,$2,$3,(SELECT $4::bigint||lineage FROM catalog_branches WHERE id=$4))`,
-			repoID, branchID, branch, sourceBranchID); err != nil {
+			repoID, branchID, branch, source.BranchID); err != nil {
+			if db.IsUniqueViolation(err) {
+				return nil, ErrBranchAlreadyExists
+			}
 			return nil, fmt.Errorf("insert branch: %w", err)
 		}
 
-		insertReturns := struct {
-			CommitID             CommitID  `db:"commit_id"`
-			MergeSourceCommit    CommitID  `db:"merge_source_commit"`
-			TransactionTimestamp time.Time `db:"transaction_timestamp"`
-		}{}
 		commitMsg := fmt.Sprintf(createBranchCommitMessageFormat, branch, sourceBranch)
-		err = tx.Get(&insertReturns, `INSERT INTO catalog_commits (branch_id,commit_id,previous_commit_id,committer,message,
-			creation_date,merge_source_branch,merge_type,lineage_commits,merge_source_commit)
-			VALUES ($1,nextval('catalog_commit_id_seq'),0,$2,$3,transaction_timestamp(),$4,'from_parent',
-				(select (select max(commit_id) from catalog_commits where branch_id=$4) ||
-					(select distinct on (branch_id) lineage_commits from catalog_commits
-						where branch_id=$4 and merge_type='from_parent' order by branch_id,commit_id desc))
-						,(select max(commit_id) from catalog_commits where branch_id=$4 ))
-			RETURNING commit_id,merge_source_commit,transaction_timestamp()`,
-			branchID, CatalogerCommitter, commitMsg, sourceBranchID)
+		commitID, creationDate, err := insertBranchCreationCommit(tx, branchID, source, commitMsg)
 		if err != nil {
-			return nil, fmt.Errorf("insert commit: %w", err)
+			return nil, err
 		}
-		reference := MakeReference(branch, insertReturns.CommitID)
-		parentReference := MakeReference(sourceBranch, insertReturns.MergeSourceCommit)
+		reference := MakeReference(branch, commitID)
 
 		commitLog := &CommitLog{
 			Committer:    CatalogerCommitter,
 			Message:      commitMsg,
-			CreationDate: insertReturns.TransactionTimestamp,
+			CreationDate: creationDate,
 			Reference:    reference,
 			Parents:      []string{parentReference},
 		}
@@ -88,3 +86,25 @@ func (c *cataloger) CreateBranch(ctx context.Context, repository, branch string,
 	commitLog := res.(*CommitLog)
 	return commitLog, nil
 }
+
+// insertBranchCreationCommit inserts the first commit of branchID, with source as its parent.  The lineage of
+// branchID must already start with the branch of source.
+func insertBranchCreationCommit(tx db.Tx, branchID int64, source lineageCommit, message string) (CommitID, time.Time, error) {
+	insertReturns := struct {
+		CommitID             CommitID  `db:"commit_id"`
+		TransactionTimestamp time.Time `db:"transaction_timestamp"`
+	}{}
+	err := tx.Get(&insertReturns, `INSERT INTO catalog_commits (branch_id,commit_id,previous_commit_id,committer,message,
+			creation_date,merge_source_branch,merge_type,lineage_commits,merge_source_commit)
+			VALUES ($1,nextval('catalog_commit_id_seq'),0,$2,$3,transaction_timestamp(),$4,'from_parent',
+				(select $5::bigint ||
+					(select distinct on (branch_id) lineage_commits from catalog_commits
+						where branch_id=$4 and merge_type='from_parent' and commit_id<=$5 order by branch_id,commit_id desc))
+						,$5)
+			RETURNING commit_id,transaction_timestamp()`,
+		branchID, CatalogerCommitter, message, source.BranchID, source.CommitID)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("insert commit: %w", err)
+	}
+	return insertReturns.CommitID, insertReturns.TransactionTimestamp, nil
+}
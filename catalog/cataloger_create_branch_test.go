@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -104,8 +105,8 @@ func TestCataloger_CreateBranch_Existing(t *testing.T) {
 	testutil.MustDo(t, "create test branch", err)
 
 	_, err = c.CreateBranch(ctx, repo, branchName, "master")
-	if err == nil {
-		t.Fatalf("CreateBranch expected to fail on create branch '%s' already exists", branchName)
+	if !errors.Is(err, ErrBranchAlreadyExists) {
+		t.Fatalf("CreateBranch err = %v, expected %s on create branch '%s' that already exists", err, ErrBranchAlreadyExists, branchName)
 	}
 }
 
@@ -127,3 +128,29 @@ func TestCataloger_CreateBranch_Parallel(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestCataloger_CreateBranch_FromTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1.0", commitLog.Reference))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "commit file2", "tester", nil)
+	testutil.MustDo(t, "commit file2", err)
+
+	branchLog, err := c.CreateBranch(ctx, repository, "b1", "v1.0")
+	testutil.MustDo(t, "create branch from tag", err)
+	if len(branchLog.Parents) != 1 || branchLog.Parents[0] != commitLog.Reference {
+		t.Fatalf("CreateBranch() parents = %v, expected %s", branchLog.Parents, commitLog.Reference)
+	}
+	testCatalogerGetEntry(t, ctx, c, repository, "b1", "file1", true)
+	testCatalogerGetEntry(t, ctx, c, repository, "b1", "file2", false)
+
+	_, err = c.CreateBranch(ctx, repository, "b2", "master~1")
+	testutil.MustDo(t, "create branch from ancestry", err)
+	testCatalogerGetEntry(t, ctx, c, repository, "b2", "file1", true)
+	testCatalogerGetEntry(t, ctx, c, repository, "b2", "file2", false)
+}
@@ -14,7 +14,7 @@ import (
 func (c *cataloger) CreateEntries(ctx context.Context, repository, branch string, entries []Entry) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return err
 	}
@@ -24,24 +24,13 @@ func (c *cataloger) CreateEntries(ctx context.Context, repository, branch string
 		return nil
 	}
 
-	// validate that we have path on each entry and remember last entry based on path (for dup remove)
-	entriesMap := make(map[string]*Entry, len(entries))
-	for i := len(entries) - 1; i >= 0; i-- {
-		p := entries[i].Path
-		if !IsNonEmptyString(p) {
-			return fmt.Errorf("entry at pos %d, path: %w", i, ErrInvalidValue)
-		}
-		entriesMap[p] = &entries[i]
-	}
-
-	// prepare a list of entries to insert without duplicates
-	entriesToInsert := make([]*Entry, 0, len(entriesMap))
+	// validate that we have path on each entry
 	for i := range entries {
-		ent := entriesMap[entries[i].Path]
-		if &entries[i] == ent {
-			entriesToInsert = append(entriesToInsert, ent)
+		if !IsValidPathLimits(entries[i].Path, c.Path) {
+			return fmt.Errorf("entry at pos %d, path: %w", i, ErrInvalidValue)
 		}
 	}
+	entriesToInsert := firstEntriesByPath(entries)
 
 	// create entries
 	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
@@ -49,36 +38,74 @@ func (c *cataloger) CreateEntries(ctx context.Context, repository, branch string
 		if err != nil {
 			return nil, err
 		}
-		// single insert per batch
-		entriesInsertSize := c.BatchWrite.EntriesInsertSize
-		for i := 0; i < len(entriesToInsert); i += entriesInsertSize {
-			sqInsert := psql.Insert("catalog_entries").
-				Columns("branch_id", "path", "physical_address", "checksum", "size", "metadata", "creation_date", "is_expired")
-			j := i + entriesInsertSize
-			if j > len(entriesToInsert) {
-				j = len(entriesToInsert)
-			}
-			for _, entry := range entriesToInsert[i:j] {
-				var dbTime sql.NullTime
-				if !entry.CreationDate.IsZero() {
-					dbTime.Time = entry.CreationDate
-					dbTime.Valid = true
-				}
-				sqInsert = sqInsert.Values(branchID, entry.Path, entry.PhysicalAddress, entry.Checksum, entry.Size, entry.Metadata,
-					sq.Expr("COALESCE(?,NOW())", dbTime), entry.Expired)
-			}
-			query, args, err := sqInsert.Suffix(`ON CONFLICT (branch_id,path,min_commit)
-DO UPDATE SET physical_address=EXCLUDED.physical_address, checksum=EXCLUDED.checksum, size=EXCLUDED.size, metadata=EXCLUDED.metadata, creation_date=EXCLUDED.creation_date, is_expired=EXCLUDED.is_expired, max_commit=catalog_max_commit_id()`).
-				ToSql()
-			if err != nil {
-				return nil, fmt.Errorf("build query: %w", err)
+		return nil, c.insertEntries(tx, branchID, entriesToInsert)
+	}, c.txOpts(ctx)...)
+	return err
+}
+
+// firstEntriesByPath returns the entries without duplicates, keeping the first entry of each path
+func firstEntriesByPath(entries []Entry) []*Entry {
+	entriesMap := make(map[string]*Entry, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		entriesMap[entries[i].Path] = &entries[i]
+	}
+	return entriesInMap(entries, entriesMap)
+}
+
+// lastEntriesByPath returns the entries without duplicates, keeping the last entry of each path
+func lastEntriesByPath(entries []Entry) []*Entry {
+	entriesMap := make(map[string]*Entry, len(entries))
+	for i := range entries {
+		entriesMap[entries[i].Path] = &entries[i]
+	}
+	return entriesInMap(entries, entriesMap)
+}
+
+// entriesInMap returns the entries kept in entriesMap, in the order of entries
+func entriesInMap(entries []Entry, entriesMap map[string]*Entry) []*Entry {
+	entriesToInsert := make([]*Entry, 0, len(entriesMap))
+	for i := range entries {
+		if &entries[i] == entriesMap[entries[i].Path] {
+			entriesToInsert = append(entriesToInsert, &entries[i])
+		}
+	}
+	return entriesToInsert
+}
+
+// insertEntries writes entries as uncommitted entries of branchID, a single insert per BatchWrite.EntriesInsertSize
+// entries.  Entries must have unique paths.
+func (c *cataloger) insertEntries(tx db.Tx, branchID int64, entriesToInsert []*Entry) error {
+	entriesInsertSize := c.BatchWrite.EntriesInsertSize
+	for i := 0; i < len(entriesToInsert); i += entriesInsertSize {
+		sqInsert := psql.Insert("catalog_entries").
+			Columns("branch_id", "path", "physical_address", "checksum", "size", "metadata", "creation_date", "is_expired", "checksums", "content_type", "expires_at")
+		j := i + entriesInsertSize
+		if j > len(entriesToInsert) {
+			j = len(entriesToInsert)
+		}
+		for _, entry := range entriesToInsert[i:j] {
+			var dbTime sql.NullTime
+			if !entry.CreationDate.IsZero() {
+				dbTime.Time = entry.CreationDate
+				dbTime.Valid = true
 			}
-			_, err = tx.Exec(query, args...)
-			if err != nil {
-				return nil, err
+			contentType := entry.ContentType
+			if contentType == "" {
+				contentType = ContentTypeByPath(entry.Path)
 			}
+			sqInsert = sqInsert.Values(branchID, entry.Path, entry.PhysicalAddress, entry.Checksum, entry.Size, entry.Metadata,
+				sq.Expr("COALESCE(?,NOW())", dbTime), entry.Expired, entry.Checksums, contentType, entry.ExpiresAt)
 		}
-		return nil, nil
-	}, c.txOpts(ctx)...)
-	return err
+		query, args, err := sqInsert.Suffix(`ON CONFLICT (branch_id,path,min_commit)
+DO UPDATE SET physical_address=EXCLUDED.physical_address, checksum=EXCLUDED.checksum, size=EXCLUDED.size, metadata=EXCLUDED.metadata, creation_date=EXCLUDED.creation_date, is_expired=EXCLUDED.is_expired, checksums=EXCLUDED.checksums, content_type=EXCLUDED.content_type, expires_at=EXCLUDED.expires_at, max_commit=catalog_max_commit_id()`).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("build query: %w", err)
+		}
+		_, err = tx.Exec(query, args...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -144,3 +144,20 @@ func TestCataloger_CreateEntries(t *testing.T) {
 		})
 	}
 }
+
+func TestCataloger_CreateEntries_DuplicatePaths(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repo := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create entries", c.CreateEntries(ctx, repo, "master", []Entry{
+		{Path: "file1", Checksum: "1231", PhysicalAddress: "first", Size: 1},
+		{Path: "file2", Checksum: "1232", PhysicalAddress: "other", Size: 2},
+		{Path: "file1", Checksum: "1233", PhysicalAddress: "second", Size: 3},
+	}))
+	// the first entry of a path is written
+	entry, err := c.GetEntry(ctx, repo, "master", "file1", GetEntryParams{})
+	testutil.MustDo(t, "get entry", err)
+	if entry.PhysicalAddress != "first" {
+		t.Fatalf("CreateEntries() of duplicate paths wrote address %s, expected first", entry.PhysicalAddress)
+	}
+}
@@ -11,8 +11,8 @@ import (
 func (c *cataloger) CreateEntry(ctx context.Context, repository, branch string, entry Entry, params CreateEntryParams) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
-		{Name: "path", IsValid: ValidatePath(entry.Path)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+		{Name: "path", IsValid: ValidatePathLimits(entry.Path, c.Path)},
 	}); err != nil {
 		return err
 	}
@@ -22,6 +22,9 @@ func (c *cataloger) CreateEntry(ctx context.Context, repository, branch string,
 		if err != nil {
 			return nil, err
 		}
+		if err := lockEntryPath(tx, branchID, entry.Path); err != nil {
+			return nil, err
+		}
 		return insertEntry(tx, branchID, &entry)
 	}, c.txOpts(ctx)...)
 	if err != nil {
@@ -52,12 +55,15 @@ func insertEntry(tx db.Tx, branchID int64, entry *Entry) (string, error) {
 		dbTime.Time = entry.CreationDate
 		dbTime.Valid = true
 	}
-	err := tx.Get(&ctid, `INSERT INTO catalog_entries (branch_id,path,physical_address,checksum,size,metadata,creation_date,is_expired)
-                        VALUES ($1,$2,$3,$4,$5,$6, COALESCE($7, NOW()), $8)
+	if entry.ContentType == "" {
+		entry.ContentType = ContentTypeByPath(entry.Path)
+	}
+	err := tx.Get(&ctid, `INSERT INTO catalog_entries (branch_id,path,physical_address,checksum,size,metadata,creation_date,is_expired,checksums,content_type,expires_at)
+                        VALUES ($1,$2,$3,$4,$5,$6, COALESCE($7, NOW()), $8, $9, $10, $11)
 			ON CONFLICT (branch_id,path,min_commit)
-			DO UPDATE SET physical_address=$3, checksum=$4, size=$5, metadata=$6, creation_date=EXCLUDED.creation_date, is_expired=EXCLUDED.is_expired, max_commit=catalog_max_commit_id()
+			DO UPDATE SET physical_address=$3, checksum=$4, size=$5, metadata=$6, creation_date=EXCLUDED.creation_date, is_expired=EXCLUDED.is_expired, checksums=$9, content_type=$10, expires_at=$11, max_commit=catalog_max_commit_id()
 			RETURNING ctid`,
-		branchID, entry.Path, entry.PhysicalAddress, entry.Checksum, entry.Size, entry.Metadata, dbTime, entry.Expired)
+		branchID, entry.Path, entry.PhysicalAddress, entry.Checksum, entry.Size, entry.Metadata, dbTime, entry.Expired, entry.Checksums, entry.ContentType, entry.ExpiresAt)
 	if err != nil {
 		return "", fmt.Errorf("insert entry: %w", err)
 	}
@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -9,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/db/params"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -235,6 +238,41 @@ func TestCataloger_CreateEntry_Dedup(t *testing.T) {
 	}
 }
 
+func TestCataloger_CreateEntry_Checksums(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	checksums := Checksums{ChecksumAlgorithmCRC32C: "yZRlqg==", ChecksumAlgorithmSHA256: "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="}
+	testutil.MustDo(t, "create entry with checksums",
+		c.CreateEntry(ctx, repository, "master", Entry{Path: "file1", Checksum: "cc", PhysicalAddress: "xx", Size: 1, Checksums: checksums}, CreateEntryParams{}))
+	ent, err := c.GetEntry(ctx, repository, "master", "file1", GetEntryParams{})
+	testutil.MustDo(t, "get entry", err)
+	if !reflect.DeepEqual(ent.Checksums, checksums) {
+		t.Fatalf("GetEntry() checksums = %v, expected %v", ent.Checksums, checksums)
+	}
+	_, err = c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+
+	// same content with other additional checksums is not a change
+	testutil.MustDo(t, "create entry without checksums",
+		c.CreateEntry(ctx, repository, "master", Entry{Path: "file1", Checksum: "cc", PhysicalAddress: "xx", Size: 1}, CreateEntryParams{}))
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+	if len(differences) != 0 {
+		t.Fatalf("DiffUncommitted() differences = %s, expected none", differences)
+	}
+
+	if err := checksums.Verify(Checksums{ChecksumAlgorithmCRC32C: "yZRlqg=="}); err != nil {
+		t.Fatalf("Verify() matching checksum err = %s", err)
+	}
+	if err := checksums.Verify(Checksums{ChecksumAlgorithmCRC32C: "AAAAAA=="}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Verify() different checksum err = %v, expected %s", err, ErrChecksumMismatch)
+	}
+	if err := (Checksums{}).Verify(Checksums{ChecksumAlgorithmSHA256: "x"}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Verify() missing checksum err = %v, expected %s", err, ErrChecksumMismatch)
+	}
+}
+
 func randomFilepath(basename string) string {
 	var sb strings.Builder
 	depth := rand.Intn(10)
@@ -256,3 +294,119 @@ func BenchmarkCataloger_CreateEntry(b *testing.B) {
 		testCatalogerCreateEntry(b, ctx, c, repo, "master", entPath, nil, "")
 	}
 }
+
+func TestCataloger_CreateEntry_ConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repo := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	conn, err := db.ConnectDB(params.Database{Driver: db.DatabaseDriver, ConnectionString: c.DbConnURI})
+	testutil.MustDo(t, "connect to database", err)
+	defer func() { _ = conn.Close() }()
+	var branchID int64
+	err = conn.Get(&branchID, `SELECT b.id FROM catalog_branches b JOIN catalog_repositories r ON r.id = b.repository_id
+		WHERE r.name = $1 AND b.name = $2`, repo, "master")
+	testutil.MustDo(t, "get branch id", err)
+
+	// hold the lock of a path, as a concurrent write to it would
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	lockDone := make(chan error, 1)
+	go func() {
+		_, err := conn.Transact(func(tx db.Tx) (interface{}, error) {
+			if err := lockEntryPath(tx, branchID, "same"); err != nil {
+				return nil, err
+			}
+			close(locked)
+			<-release
+			return nil, nil
+		})
+		lockDone <- err
+	}()
+	<-locked
+
+	// writes to other paths proceed
+	otherDone := make(chan error, 1)
+	go func() {
+		otherDone <- c.CreateEntry(ctx, repo, "master", Entry{Path: "other", Checksum: "aa", PhysicalAddress: "aa"}, CreateEntryParams{})
+	}()
+	select {
+	case err := <-otherDone:
+		testutil.MustDo(t, "create entry on other path", err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("write to other path blocked by lock")
+	}
+
+	// writes to the same path wait for the lock
+	sameDone := make(chan error, 1)
+	go func() {
+		sameDone <- c.CreateEntry(ctx, repo, "master", Entry{Path: "same", Checksum: "bb", PhysicalAddress: "bb"}, CreateEntryParams{})
+	}()
+	select {
+	case err := <-sameDone:
+		t.Fatalf("write to locked path completed while locked, err = %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(release)
+	testutil.MustDo(t, "release lock", <-lockDone)
+	select {
+	case err := <-sameDone:
+		testutil.MustDo(t, "create entry on same path", err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("write to same path not released")
+	}
+	testCatalogerGetEntry(t, ctx, c, repo, "master", "same", true)
+}
+
+func TestCataloger_CreateEntry_ContentType(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repo := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		want        string
+	}{
+		{name: "set", path: "data.json", contentType: "text/csv", want: "text/csv"},
+		{name: "by extension", path: "data.json.gz", want: "application/gzip"},
+		{name: "default", path: "data", want: DefaultContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.CreateEntry(ctx, repo, "master", Entry{
+				Path:            tt.path,
+				Checksum:        "aa",
+				PhysicalAddress: "aa",
+				ContentType:     tt.contentType,
+			}, CreateEntryParams{})
+			testutil.MustDo(t, "create entry", err)
+			ent, err := c.GetEntry(ctx, repo, "master", tt.path, GetEntryParams{})
+			testutil.MustDo(t, "get entry", err)
+			if ent.ContentType != tt.want {
+				t.Fatalf("GetEntry() content type = %s, expected %s", ent.ContentType, tt.want)
+			}
+		})
+	}
+
+	// a content type change is read back but is not a difference
+	_, err := c.Commit(ctx, repo, "master", "commit entries", "tester", nil)
+	testutil.MustDo(t, "commit entries", err)
+	testutil.MustDo(t, "change content type", c.CreateEntry(ctx, repo, "master", Entry{
+		Path:            "data",
+		Checksum:        "aa",
+		PhysicalAddress: "aa",
+		ContentType:     "text/plain",
+	}, CreateEntryParams{}))
+	ent, err := c.GetEntry(ctx, repo, "master", "data", GetEntryParams{})
+	testutil.MustDo(t, "get changed entry", err)
+	if ent.ContentType != "text/plain" {
+		t.Fatalf("GetEntry() changed content type = %s, expected text/plain", ent.ContentType)
+	}
+	differences, _, err := c.DiffUncommitted(ctx, repo, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+	if len(differences) != 0 {
+		t.Fatalf("DiffUncommitted() = %v, expected no differences for a content type change", differences)
+	}
+}
@@ -7,11 +7,11 @@ import (
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) CreateMultipartUpload(ctx context.Context, repository string, uploadID, path, physicalAddress string, creationTime time.Time) error {
+func (c *cataloger) CreateMultipartUpload(ctx context.Context, repository string, uploadID, path, physicalAddress string, creationTime time.Time, params CreateMultipartUploadParams) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
 		{Name: "uploadID", IsValid: ValidateUploadID(uploadID)},
-		{Name: "path", IsValid: ValidatePath(path)},
+		{Name: "path", IsValid: ValidatePathLimits(path, c.Path)},
 		{Name: "physicalAddress", IsValid: ValidatePhysicalAddress(physicalAddress)},
 	}); err != nil {
 		return err
@@ -22,9 +22,9 @@ func (c *cataloger) CreateMultipartUpload(ctx context.Context, repository string
 		if err != nil {
 			return nil, err
 		}
-		_, err = tx.Exec(`INSERT INTO catalog_multipart_uploads (repository_id,upload_id,path,creation_date,physical_address)
-			VALUES ($1, $2, $3, $4, $5)`,
-			repoID, uploadID, path, creationTime, physicalAddress)
+		_, err = tx.Exec(`INSERT INTO catalog_multipart_uploads (repository_id,upload_id,path,creation_date,physical_address,metadata,content_type)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			repoID, uploadID, path, creationTime, physicalAddress, params.Metadata, params.ContentType)
 		return nil, err
 	}, c.txOpts(ctx)...)
 	return err
@@ -16,7 +16,7 @@ func TestCataloger_CreateMultipartUpload(t *testing.T) {
 	if err := c.CreateRepository(ctx, "repo1", "s3://bucket1", "master"); err != nil {
 		t.Fatal("create repository for testing", err)
 	}
-	if err := c.CreateMultipartUpload(ctx, "repo1", "uploadX", "/pathX", "/fileX", time.Now()); err != nil {
+	if err := c.CreateMultipartUpload(ctx, "repo1", "uploadX", "/pathX", "/fileX", time.Now(), CreateMultipartUploadParams{}); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
@@ -60,7 +60,7 @@ func TestCataloger_CreateMultipartUpload(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := c.CreateMultipartUpload(ctx, tt.args.repository, tt.args.uploadID, tt.args.path, tt.args.physicalAddress, tt.args.creationTime)
+			err := c.CreateMultipartUpload(ctx, tt.args.repository, tt.args.uploadID, tt.args.path, tt.args.physicalAddress, tt.args.creationTime, CreateMultipartUploadParams{})
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("CreateMultipartUpload() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -13,9 +13,9 @@ const (
 
 func (c *cataloger) CreateRepository(ctx context.Context, repository string, storageNamespace string, branch string) error {
 	if err := Validate(ValidateFields{
-		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "repository", IsValid: ValidateNewRepositoryName(repository)},
 		{Name: "storageNamespace", IsValid: ValidateStorageNamespace(storageNamespace)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return err
 	}
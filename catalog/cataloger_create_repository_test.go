@@ -41,6 +41,18 @@ func TestCataloger_CreateRepo(t *testing.T) {
 			wantErr: true,
 			asErr:   ErrInvalidValue,
 		},
+		{
+			name:    "not a bucket name",
+			args:    args{name: "repo-", storage: "s3://bucket1", branch: "master"},
+			wantErr: true,
+			asErr:   ErrInvalidValue,
+		},
+		{
+			name:    "consecutive hyphens",
+			args:    args{name: "my--repo", storage: "s3://bucket1", branch: "master"},
+			wantErr: true,
+			asErr:   ErrInvalidValue,
+		},
 		{
 			name:    "missing storage",
 			args:    args{name: "repo1", storage: "", branch: "master"},
@@ -0,0 +1,56 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// CreateTag tags the commit commitID with tagName.  Tags are immutable, an existing tag returns ErrTagAlreadyExists.
+func (c *cataloger) CreateTag(ctx context.Context, repository, tagName, commitID string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "tagName", IsValid: ValidateTagName(tagName)},
+		{Name: "commitID", IsValid: ValidateReference(commitID)},
+	}); err != nil {
+		return err
+	}
+	ref, err := ParseRef(commitID)
+	if err != nil {
+		return err
+	}
+	// tags point to a specific commit, not to a branch
+	if ref.CommitID <= UncommittedID {
+		return fmt.Errorf("%w: commitID", ErrInvalidValue)
+	}
+	_, err = c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		if err != nil {
+			return nil, err
+		}
+		var commitExists bool
+		err = tx.Get(&commitExists, `SELECT EXISTS (SELECT 1 FROM catalog_commits WHERE branch_id=$1 AND commit_id=$2)`,
+			branchID, ref.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf("commit: %w", err)
+		}
+		if !commitExists {
+			return nil, ErrCommitNotFound
+		}
+		_, err = tx.Exec(`INSERT INTO catalog_tags (repository_id, name, branch_id, commit_id) VALUES ($1,$2,$3,$4)`,
+			repoID, tagName, branchID, ref.CommitID)
+		if db.IsUniqueViolation(err) {
+			return nil, ErrTagAlreadyExists
+		}
+		if err != nil {
+			return nil, fmt.Errorf("insert tag: %w", err)
+		}
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	return err
+}
@@ -0,0 +1,90 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+
+	tests := []struct {
+		name     string
+		tagName  string
+		commitID string
+		wantErr  error
+	}{
+		{name: "tag", tagName: "v1.0", commitID: commitLog.Reference},
+		{name: "existing", tagName: "v1.0", commitID: commitLog.Reference, wantErr: ErrTagAlreadyExists},
+		{name: "invalid name", tagName: "v1 0", commitID: commitLog.Reference, wantErr: ErrInvalidValue},
+		{name: "branch", tagName: "v2.0", commitID: "master", wantErr: ErrInvalidValue},
+		{name: "unknown commit", tagName: "v3.0", commitID: MakeReference("master", 9999), wantErr: ErrCommitNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.CreateTag(ctx, repository, tt.tagName, tt.commitID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateTag() err = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tagCommit, err := c.GetTag(ctx, repository, "v1.0")
+	testutil.MustDo(t, "get tag", err)
+	if tagCommit.Reference != commitLog.Reference {
+		t.Fatalf("GetTag() reference = %s, expected %s", tagCommit.Reference, commitLog.Reference)
+	}
+	if _, err := c.GetTag(ctx, repository, "v2.0"); !errors.Is(err, ErrTagNotFound) {
+		t.Fatalf("GetTag() of missing tag err = %v, expected %s", err, ErrTagNotFound)
+	}
+
+	testutil.MustDo(t, "delete tag", c.DeleteTag(ctx, repository, "v1.0"))
+	if err := c.DeleteTag(ctx, repository, "v1.0"); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("DeleteTag() of deleted tag err = %v, expected %s", err, db.ErrNotFound)
+	}
+	// a deleted tag name can be reused
+	testutil.MustDo(t, "recreate tag", c.CreateTag(ctx, repository, "v1.0", commitLog.Reference))
+}
+
+func TestCataloger_TagReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1.0", commitLog.Reference))
+	testutil.MustDo(t, "create tag named as branch", c.CreateTag(ctx, repository, "master", commitLog.Reference))
+	// changes after the tagged commit
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+
+	testCatalogerGetEntry(t, ctx, c, repository, "v1.0", "file1", true)
+	testCatalogerGetEntry(t, ctx, c, repository, "v1.0", "file2", false)
+	entries, _, err := c.ListEntries(ctx, repository, "v1.0", "", "", "", -1)
+	testutil.MustDo(t, "list tag entries", err)
+	if len(entries) != 1 || entries[0].Path != "file1" {
+		t.Fatalf("ListEntries() of tag = %v, expected file1", entries)
+	}
+	tagCommit, err := c.GetCommit(ctx, repository, "v1.0")
+	testutil.MustDo(t, "get tag commit", err)
+	if tagCommit.Reference != commitLog.Reference {
+		t.Fatalf("GetCommit() of tag reference = %s, expected %s", tagCommit.Reference, commitLog.Reference)
+	}
+	differences, _, err := c.DiffCommits(ctx, repository, "v1.0", commitLog.Reference, -1, "")
+	testutil.MustDo(t, "diff tag and commit", err)
+	if len(differences) != 0 {
+		t.Fatalf("DiffCommits() of tag and its commit = %s, expected none", differences)
+	}
+
+	// branches take precedence over tags
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "file2", true)
+}
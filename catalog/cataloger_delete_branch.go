@@ -7,10 +7,12 @@ import (
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string) error {
+// DeleteBranch deletes branch and its uncommitted entries.  The repository default branch is never deleted, nor is
+// a branch with tagged commits.  A branch with uncommitted changes is deleted only if force is set.
+func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string, force bool) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return err
 	}
@@ -21,6 +23,35 @@ func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string)
 			return nil, err
 		}
 
+		var defaultBranchID int64
+		err = tx.Get(&defaultBranchID, `SELECT default_branch FROM catalog_repositories WHERE name=$1 AND deleted_at IS NULL`, repository)
+		if err != nil {
+			return nil, fmt.Errorf("default branch: %w", err)
+		}
+		if branchID == defaultBranchID {
+			return nil, ErrCannotDeleteDefaultBranch
+		}
+
+		if !force {
+			hasChanges, err := hasUncommittedChanges(tx, branchID)
+			if err != nil {
+				return nil, fmt.Errorf("uncommitted changes: %w", err)
+			}
+			if hasChanges {
+				return nil, ErrBranchHasUncommittedChanges
+			}
+		}
+
+		// deleting the branch would delete its tagged commits and their entries
+		var hasTags bool
+		err = tx.Get(&hasTags, `SELECT EXISTS (SELECT 1 FROM catalog_tags WHERE branch_id=$1)`, branchID)
+		if err != nil {
+			return nil, fmt.Errorf("tags: %w", err)
+		}
+		if hasTags {
+			return nil, ErrBranchHasTags
+		}
+
 		// default branch doesn't have parents
 		var legacyCount int
 		err = tx.Get(&legacyCount, `SELECT array_length(lineage,1) FROM catalog_branches WHERE id=$1`, branchID)
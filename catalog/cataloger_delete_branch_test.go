@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
 )
 
 func TestCataloger_DeleteBranch(t *testing.T) {
@@ -41,20 +42,35 @@ func TestCataloger_DeleteBranch(t *testing.T) {
 	type args struct {
 		repository string
 		branch     string
+		force      bool
 	}
 	tests := []struct {
 		name    string
 		args    args
 		wantErr bool
+		asErr   error
 	}{
 		{
 			name:    "delete default branch",
 			args:    args{repository: "repo1", branch: "master"},
 			wantErr: true,
+			asErr:   ErrCannotDeleteDefaultBranch,
 		},
 		{
-			name:    "delete branch",
+			name:    "force delete default branch",
+			args:    args{repository: "repo1", branch: "master", force: true},
+			wantErr: true,
+			asErr:   ErrCannotDeleteDefaultBranch,
+		},
+		{
+			name:    "delete branch with uncommitted changes",
 			args:    args{repository: "repo1", branch: "b1"},
+			wantErr: true,
+			asErr:   ErrBranchHasUncommittedChanges,
+		},
+		{
+			name:    "force delete branch with uncommitted changes",
+			args:    args{repository: "repo1", branch: "b1", force: true},
 			wantErr: false,
 		},
 		{
@@ -75,10 +91,13 @@ func TestCataloger_DeleteBranch(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := c.DeleteBranch(ctx, tt.args.repository, tt.args.branch)
+			err := c.DeleteBranch(ctx, tt.args.repository, tt.args.branch, tt.args.force)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("DeleteBranch() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.asErr != nil && !errors.Is(err, tt.asErr) {
+				t.Fatalf("DeleteBranch() error = %v, expected %s", err, tt.asErr)
+			}
 			if err != nil {
 				return
 			}
@@ -91,6 +110,29 @@ func TestCataloger_DeleteBranch(t *testing.T) {
 	}
 }
 
+func TestCataloger_DeleteBranch_Tagged(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "branch1", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1", commitLog.Reference))
+
+	for _, force := range []bool{false, true} {
+		if err := c.DeleteBranch(ctx, repository, "branch1", force); !errors.Is(err, ErrBranchHasTags) {
+			t.Fatalf("DeleteBranch() with force=%t of tagged branch err = %v, expected %s", force, err, ErrBranchHasTags)
+		}
+	}
+	if _, err := c.GetEntry(ctx, repository, "v1", "file1", GetEntryParams{}); err != nil {
+		t.Fatalf("GetEntry() of tagged entry after failed delete err = %s", err)
+	}
+
+	testutil.MustDo(t, "delete tag", c.DeleteTag(ctx, repository, "v1"))
+	testutil.MustDo(t, "delete branch without tags", c.DeleteBranch(ctx, repository, "branch1", false))
+}
+
 func TestCataloger_DeleteBranchTwice(t *testing.T) {
 	ctx := context.Background()
 	c := testCataloger(t)
@@ -106,13 +148,30 @@ func TestCataloger_DeleteBranchTwice(t *testing.T) {
 	// delete twice (checking double delete) in reverse order
 	for i := numBranches; i > 0; i-- {
 		branchName := fmt.Sprintf("branch%d", i)
-		err := c.DeleteBranch(ctx, repo, branchName)
+		err := c.DeleteBranch(ctx, repo, branchName, false)
 		if err != nil {
 			t.Fatal("Expected delete to succeed on", branchName, err)
 		}
-		err = c.DeleteBranch(ctx, repo, branchName)
+		err = c.DeleteBranch(ctx, repo, branchName, false)
 		if err == nil {
 			t.Fatal("Expected delete to fail on", branchName, err)
 		}
 	}
 }
+
+func TestCataloger_DeleteBranch_Committed(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "branch1", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+
+	// committed changes do not need force
+	testutil.MustDo(t, "delete branch", c.DeleteBranch(ctx, repository, "branch1", false))
+	_, err = c.GetBranchReference(ctx, repository, "branch1")
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetBranchReference() after delete err = %v, expected %s", err, db.ErrNotFound)
+	}
+}
@@ -12,7 +12,7 @@ import (
 func (c *cataloger) DeleteEntry(ctx context.Context, repository, branch string, path string) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return err
 	}
@@ -24,6 +24,9 @@ func (c *cataloger) DeleteEntry(ctx context.Context, repository, branch string,
 		if err != nil {
 			return nil, err
 		}
+		if err := lockEntryPath(tx, branchID, path); err != nil {
+			return nil, err
+		}
 
 		// delete uncommitted entry, if found first
 		res, err := tx.Exec("DELETE FROM catalog_entries WHERE branch_id=$1 AND path=$2 AND min_commit=0 AND max_commit=catalog_max_commit_id()",
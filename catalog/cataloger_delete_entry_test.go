@@ -5,7 +5,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
 )
 
 func TestCataloger_DeleteEntry(t *testing.T) {
@@ -119,3 +121,68 @@ func testDeleteEntryCommitAndExpectNotFound(t *testing.T, ctx context.Context, c
 		t.Fatalf("DeleteEntry() get entry err = %s, want = %s", err, wantErr)
 	}
 }
+
+func TestCataloger_DeleteEntry_HiddenUntilCommit(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	for _, p := range []string{"dir/file1", "dir/file2", "file3"} {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "add files", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "feature", "master")
+
+	// tombstone on the branch that committed the entry, and on a branch that sees it through its lineage
+	testutil.MustDo(t, "delete on master", c.DeleteEntry(ctx, repository, "master", "dir/file1"))
+	testutil.MustDo(t, "delete on feature", c.DeleteEntry(ctx, repository, "feature", "file3"))
+
+	listPaths := func(reference, prefix, delimiter string) []string {
+		t.Helper()
+		entries, _, err := c.ListEntries(ctx, repository, reference, prefix, "", delimiter, -1)
+		testutil.MustDo(t, "list entries", err)
+		var paths []string
+		for _, entry := range entries {
+			paths = append(paths, entry.Path)
+		}
+		return paths
+	}
+	tests := []struct {
+		name      string
+		reference string
+		prefix    string
+		delimiter string
+		want      []string
+	}{
+		{name: "master", reference: "master", want: []string{"dir/file2", "file3"}},
+		{name: "master by level", reference: "master", prefix: "dir/", delimiter: "/", want: []string{"dir/file2"}},
+		{name: "master committed", reference: MakeReference("master", CommittedID), want: []string{"dir/file1", "dir/file2", "file3"}},
+		{name: "feature", reference: "feature", want: []string{"dir/file1", "dir/file2"}},
+		{name: "feature by level", reference: "feature", delimiter: "/", want: []string{"dir/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(listPaths(tt.reference, tt.prefix, tt.delimiter), tt.want); diff != nil {
+				t.Fatal("ListEntries()", diff)
+			}
+		})
+	}
+
+	if _, err := c.GetEntry(ctx, repository, "master", "dir/file1", GetEntryParams{}); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetEntry() of deleted entry err = %v, expected %s", err, db.ErrNotFound)
+	}
+	for branch, path := range map[string]string{"master": "dir/file1", "feature": "file3"} {
+		differences, _, err := c.DiffUncommitted(ctx, repository, branch, "", -1, "")
+		testutil.MustDo(t, "diff uncommitted", err)
+		expected := Differences{{Type: DifferenceTypeRemoved, Path: path}}
+		if diff := deep.Equal(testDifferencesTypeAndPath(differences), expected); diff != nil {
+			t.Errorf("DiffUncommitted() on %s: %s", branch, diff)
+		}
+	}
+
+	// a directory is not listed once all its entries are deleted
+	testutil.MustDo(t, "delete last entry in dir", c.DeleteEntry(ctx, repository, "master", "dir/file2"))
+	if diff := deep.Equal(listPaths("master", "", "/"), []string{"file3"}); diff != nil {
+		t.Fatal("ListEntries() after deleting dir", diff)
+	}
+}
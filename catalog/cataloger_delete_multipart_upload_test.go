@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -14,9 +15,12 @@ func TestCataloger_DeleteMultipartUpload(t *testing.T) {
 	if err := c.CreateRepository(ctx, "repo1", "s3://bucket1", "master"); err != nil {
 		t.Fatal("create repository for testing", err)
 	}
-	if err := c.CreateMultipartUpload(ctx, "repo1", "uploadX", "/pathX", "/fileX", time.Now()); err != nil {
+	if err := c.CreateMultipartUpload(ctx, "repo1", "uploadX", "/pathX", "/fileX", time.Now(), CreateMultipartUploadParams{}); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
+	if err := c.PutMultipartUploadPart(ctx, "repo1", "uploadX", MultipartUploadPart{PartNumber: 1, ETag: "aa", Size: 1, PhysicalAddress: "/fileX"}); err != nil {
+		t.Fatal("put multipart upload part for testing", err)
+	}
 
 	type args struct {
 		repository string
@@ -33,9 +37,16 @@ func TestCataloger_DeleteMultipartUpload(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := c.DeleteMultipartUpload(ctx, tt.args.repository, tt.args.uploadID); (err != nil) != tt.wantErr {
+			err := c.DeleteMultipartUpload(ctx, tt.args.repository, tt.args.uploadID)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("DeleteMultipartUpload() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if err != nil {
+				return
+			}
+			if _, err := c.ListMultipartUploadParts(ctx, tt.args.repository, tt.args.uploadID); !errors.Is(err, ErrMultipartUploadNotFound) {
+				t.Errorf("ListMultipartUploadParts() after delete error = %v, expected %s", err, ErrMultipartUploadNotFound)
+			}
 		})
 	}
 }
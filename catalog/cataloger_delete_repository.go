@@ -6,6 +6,8 @@ import (
 	"github.com/treeverse/lakefs/db"
 )
 
+// DeleteRepository hides the repository, it can be restored by UndeleteRepository until the delete retention
+// period ends and PurgeDeletedRepositories removes it. The repository name stays taken until then.
 func (c *cataloger) DeleteRepository(ctx context.Context, repository string) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
@@ -14,7 +16,8 @@ func (c *cataloger) DeleteRepository(ctx context.Context, repository string) err
 	}
 
 	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		res, err := tx.Exec(`DELETE FROM catalog_repositories WHERE name=$1`, repository)
+		res, err := tx.Exec(`UPDATE catalog_repositories SET deleted_at=transaction_timestamp()
+			WHERE name=$1 AND deleted_at IS NULL`, repository)
 		if err != nil {
 			return nil, err
 		}
@@ -25,5 +28,56 @@ func (c *cataloger) DeleteRepository(ctx context.Context, repository string) err
 		}
 		return nil, nil
 	}, c.txOpts(ctx)...)
-	return err
+	if err != nil {
+		return err
+	}
+	c.cache.EvictRepository(repository)
+	return nil
+}
+
+// UndeleteRepository restores a repository deleted within the delete retention period
+func (c *cataloger) UndeleteRepository(ctx context.Context, repository string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		res, err := tx.Exec(`UPDATE catalog_repositories SET deleted_at=NULL
+			WHERE name=$1 AND deleted_at > transaction_timestamp() - $2 * interval '1 second'`,
+			repository, c.Repository.DeleteRetention.Seconds())
+		if err != nil {
+			return nil, err
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if affected != 1 {
+			return nil, ErrRepositoryNotFound
+		}
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return err
+	}
+	c.cache.EvictRepository(repository)
+	return nil
+}
+
+// PurgeDeletedRepositories permanently removes the repositories deleted before the delete retention period,
+// along with their branches, commits and entries. Returns the number of repositories removed.
+func (c *cataloger) PurgeDeletedRepositories(ctx context.Context) (int64, error) {
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		res, err := tx.Exec(`DELETE FROM catalog_repositories
+			WHERE deleted_at <= transaction_timestamp() - $1 * interval '1 second'`,
+			c.Repository.DeleteRetention.Seconds())
+		if err != nil {
+			return nil, err
+		}
+		return res.RowsAffected()
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.(int64), nil
 }
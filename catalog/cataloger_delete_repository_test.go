@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/treeverse/lakefs/catalog/params"
 	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
 )
 
 func TestCataloger_DeleteRepository(t *testing.T) {
@@ -55,3 +58,57 @@ func TestCataloger_DeleteRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestCataloger_UndeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+
+	testutil.MustDo(t, "delete repository", c.DeleteRepository(ctx, repository))
+	repos, _, err := c.ListRepositories(ctx, -1, "")
+	testutil.MustDo(t, "list repositories", err)
+	for _, repo := range repos {
+		if repo.Name == repository {
+			t.Fatalf("ListRepositories() lists deleted repository %s", repository)
+		}
+	}
+	if err := c.DeleteRepository(ctx, repository); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("DeleteRepository() of deleted repository err = %v, expected %s", err, db.ErrNotFound)
+	}
+
+	testutil.MustDo(t, "undelete repository", c.UndeleteRepository(ctx, repository))
+	if _, err := c.GetRepository(ctx, repository); err != nil {
+		t.Fatalf("GetRepository() after undelete err = %v", err)
+	}
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "file1", true)
+
+	if err := c.UndeleteRepository(ctx, repository); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("UndeleteRepository() of existing repository err = %v, expected %s", err, db.ErrNotFound)
+	}
+}
+
+func TestCataloger_PurgeDeletedRepositories(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t, WithParams(params.Catalog{
+		Repository: params.Repository{DeleteRetention: time.Nanosecond},
+	}))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	otherRepository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	testutil.MustDo(t, "delete repository", c.DeleteRepository(ctx, repository))
+	purged, err := c.PurgeDeletedRepositories(ctx)
+	testutil.MustDo(t, "purge deleted repositories", err)
+	// other tests may have deleted repositories in the same database
+	if purged < 1 {
+		t.Fatalf("PurgeDeletedRepositories() = %d, expected at least 1", purged)
+	}
+	if err := c.UndeleteRepository(ctx, repository); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("UndeleteRepository() of purged repository err = %v, expected %s", err, db.ErrNotFound)
+	}
+	if _, err := c.GetRepository(ctx, otherRepository); err != nil {
+		t.Fatalf("GetRepository() of other repository err = %v", err)
+	}
+}
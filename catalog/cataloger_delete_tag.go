@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) DeleteTag(ctx context.Context, repository, tagName string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "tagName", IsValid: ValidateTagName(tagName)},
+	}); err != nil {
+		return err
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		res, err := tx.Exec(`DELETE FROM catalog_tags WHERE repository_id=$1 AND name=$2`, repoID, tagName)
+		if err != nil {
+			return nil, fmt.Errorf("delete tag: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if affected != 1 {
+			return nil, ErrTagNotFound
+		}
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/treeverse/lakefs/db"
@@ -16,18 +17,24 @@ const (
 	diffResultsTableName = "catalog_diff_results"
 )
 
+// Diff lists the differences between leftBranch and rightBranch.  When either side is a tag or a commit reference
+// instead of a branch, the references are compared by DiffCommits.
 func (c *cataloger) Diff(ctx context.Context, repository string, leftBranch string, rightBranch string, limit int, after string) (Differences, bool, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "leftBranch", IsValid: ValidateBranchName(leftBranch)},
-		{Name: "rightBranch", IsValid: ValidateBranchName(rightBranch)},
+		{Name: "leftBranch", IsValid: ValidateReference(leftBranch)},
+		{Name: "rightBranch", IsValid: ValidateReference(rightBranch)},
 	}); err != nil {
 		return nil, false, err
 	}
+	if !IsValidBranchName(leftBranch) || !IsValidBranchName(rightBranch) {
+		return c.DiffCommits(ctx, repository, leftBranch, rightBranch, limit, after)
+	}
 
 	if limit < 0 || limit > DiffMaxLimit {
 		limit = DiffMaxLimit
 	}
+	start := time.Now()
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		leftID, err := c.getBranchIDCache(tx, repository, leftBranch)
 		if err != nil {
@@ -43,11 +50,16 @@ func (c *cataloger) Diff(ctx context.Context, repository string, leftBranch stri
 		}
 		return getDiffDifferences(tx, limit+1, after)
 	}, c.txOpts(ctx)...)
+	if errors.Is(err, ErrBranchNotFound) {
+		// either side may be a tag
+		return c.DiffCommits(ctx, repository, leftBranch, rightBranch, limit, after)
+	}
 	if err != nil {
 		return nil, false, err
 	}
 	differences := res.(Differences)
 	hasMore := paginateSlice(&differences, limit)
+	c.metrics.RecordDiff(DiffOperationBranches, time.Since(start), len(differences))
 	return differences, hasMore, nil
 }
 
@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// DiffCommits lists the differences between the objects visible in leftReference and rightReference.
+// Objects found only on the left are reported as added, objects found only on the right as removed.
+func (c *cataloger) DiffCommits(ctx context.Context, repository, leftReference, rightReference string, limit int, after string) (Differences, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "leftReference", IsValid: ValidateReference(leftReference)},
+		{Name: "rightReference", IsValid: ValidateReference(rightReference)},
+	}); err != nil {
+		return nil, false, err
+	}
+	leftRef, err := ParseRef(leftReference)
+	if err != nil {
+		return nil, false, fmt.Errorf("left reference: %w", err)
+	}
+	rightRef, err := ParseRef(rightReference)
+	if err != nil {
+		return nil, false, fmt.Errorf("right reference: %w", err)
+	}
+
+	if limit < 0 || limit > DiffMaxLimit {
+		limit = DiffMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		leftRef, err := c.resolveTagRef(tx, repository, leftRef)
+		if err != nil {
+			return nil, fmt.Errorf("left reference: %w", err)
+		}
+		rightRef, err := c.resolveTagRef(tx, repository, rightRef)
+		if err != nil {
+			return nil, fmt.Errorf("right reference: %w", err)
+		}
+		if *leftRef == *rightRef {
+			// nothing to compare, just verify the branch exists
+			if _, err := c.getBranchIDCache(tx, repository, leftRef.Branch); err != nil {
+				return nil, err
+			}
+			return Differences(nil), nil
+		}
+		leftQ, err := c.sqDiffRefEntries(tx, repository, *leftRef)
+		if err != nil {
+			return nil, fmt.Errorf("left reference: %w", err)
+		}
+		rightQ, err := c.sqDiffRefEntries(tx, repository, *rightRef)
+		if err != nil {
+			return nil, fmt.Errorf("right reference: %w", err)
+		}
+		diffQ := sq.Select("CASE WHEN r.path IS NULL THEN 0 WHEN l.path IS NULL THEN 1 ELSE 2 END AS diff_type",
+			"COALESCE(l.path, r.path) AS path").
+			FromSelect(leftQ, "l").
+			JoinClause(rightQ.Prefix("FULL OUTER JOIN (").Suffix(") AS r ON l.path=r.path")).
+			Where("l.path IS NULL OR r.path IS NULL OR NOT " + sqSameContent("l", "r") + " OR l.metadata IS DISTINCT FROM r.metadata")
+		sql, args, err := psql.Select("*").
+			FromSelect(diffQ, "d").
+			Where(sq.Gt{"path": after}).
+			OrderBy("path").
+			Limit(uint64(limit + 1)).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		var result Differences
+		if err := tx.Select(&result, sql, args...); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	differences := res.(Differences)
+	hasMore := paginateSlice(&differences, limit)
+	return differences, hasMore, nil
+}
+
+// sqDiffRefEntries selects path, checksum, physical address and metadata of the objects visible in ref
+func (c *cataloger) sqDiffRefEntries(tx db.Tx, repository string, ref Ref) (sq.SelectBuilder, error) {
+	branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+	if err != nil {
+		return sq.SelectBuilder{}, err
+	}
+	return sqDiffCommitEntries(tx, branchID, ref.CommitID)
+}
+
+// sqDiffCommitEntries selects path, checksum, physical address and metadata of the objects visible in commitID of
+// branchID
+func sqDiffCommitEntries(tx db.Tx, branchID int64, commitID CommitID) (sq.SelectBuilder, error) {
+	lineage, err := getLineage(tx, branchID, commitID)
+	if err != nil {
+		return sq.SelectBuilder{}, fmt.Errorf("get lineage: %w", err)
+	}
+	return sq.Select("path", "checksum", "physical_address", "metadata").
+		FromSelect(sqEntriesLineage(branchID, commitID, lineage), "entries").
+		Where("NOT is_deleted"), nil
+}
@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DiffCommits(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	firstCommit, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+
+	testutil.MustDo(t, "delete file",
+		c.DeleteEntry(ctx, repository, "master", "/file0"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+	secondCommit, err := c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	// commit on a branch of master
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file4", nil, "")
+	branchCommit, err := c.Commit(ctx, repository, "branch1", "branch commit", "tester", nil)
+	testutil.MustDo(t, "branch commit", err)
+
+	tests := []struct {
+		name  string
+		left  string
+		right string
+		want  Differences
+	}{
+		{
+			name:  "same commit",
+			left:  firstCommit.Reference,
+			right: firstCommit.Reference,
+			want:  Differences{},
+		},
+		{
+			name:  "same branch",
+			left:  "master",
+			right: "master",
+			want:  Differences{},
+		},
+		{
+			name:  "newer to older",
+			left:  secondCommit.Reference,
+			right: firstCommit.Reference,
+			want: Differences{
+				Difference{Type: DifferenceTypeRemoved, Path: "/file0"},
+				Difference{Type: DifferenceTypeChanged, Path: "/file1"},
+				Difference{Type: DifferenceTypeAdded, Path: "/file3"},
+			},
+		},
+		{
+			name:  "older to newer",
+			left:  firstCommit.Reference,
+			right: secondCommit.Reference,
+			want: Differences{
+				Difference{Type: DifferenceTypeAdded, Path: "/file0"},
+				Difference{Type: DifferenceTypeChanged, Path: "/file1"},
+				Difference{Type: DifferenceTypeRemoved, Path: "/file3"},
+			},
+		},
+		{
+			name:  "across branches",
+			left:  branchCommit.Reference,
+			right: firstCommit.Reference,
+			want: Differences{
+				Difference{Type: DifferenceTypeRemoved, Path: "/file0"},
+				Difference{Type: DifferenceTypeChanged, Path: "/file1"},
+				Difference{Type: DifferenceTypeAdded, Path: "/file3"},
+				Difference{Type: DifferenceTypeAdded, Path: "/file4"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const limit = 2
+			differences := Differences{}
+			var after string
+			for {
+				res, hasMore, err := c.DiffCommits(ctx, repository, tt.left, tt.right, limit, after)
+				testutil.MustDo(t, "diff commits", err)
+				if len(res) > limit {
+					t.Fatalf("DiffCommits() result length=%d, expected no more than %d", len(res), limit)
+				}
+				differences = append(differences, res...)
+				if !hasMore {
+					break
+				}
+				after = res[len(res)-1].Path
+			}
+			if diff := deep.Equal(differences, tt.want); diff != nil {
+				t.Fatal("DiffCommits", diff)
+			}
+		})
+	}
+}
+
+func TestCataloger_DiffCommits_MetadataChange(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", Metadata{"k": "v"}, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", Metadata{"k": "v"}, "")
+	firstCommit, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", Metadata{"k": "v2"}, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", Metadata{"k": "v"}, "")
+	secondCommit, err := c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	differences, _, err := c.DiffCommits(ctx, repository, firstCommit.Reference, secondCommit.Reference, -1, "")
+	testutil.MustDo(t, "diff commits", err)
+	expected := Differences{{Type: DifferenceTypeChanged, Path: "/file0"}}
+	if diff := deep.Equal(differences, expected); diff != nil {
+		t.Fatal("DiffCommits()", diff)
+	}
+}
+
+func TestCataloger_DiffCommits_MissingBranch(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	_, _, err := c.DiffCommits(ctx, repository, "nobranch", "nobranch", -1, "")
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("DiffCommits() err = %s, expected not found", err)
+	}
+}
@@ -0,0 +1,94 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// DiffRefs lists the changes leftRef introduces since it diverged from rightRef: the differences between the
+// merge base of both refs and leftRef, as reported by DiffCommits.  Changes made on rightRef after the merge base
+// are not reported.  Both refs are resolved by ResolveRef.
+func (c *cataloger) DiffRefs(ctx context.Context, repository, leftRef, rightRef string, limit int, after string) (Differences, bool, error) {
+	leftReference, err := c.ResolveRef(ctx, repository, leftRef)
+	if err != nil {
+		return nil, false, fmt.Errorf("left reference: %w", err)
+	}
+	rightReference, err := c.ResolveRef(ctx, repository, rightRef)
+	if err != nil {
+		return nil, false, fmt.Errorf("right reference: %w", err)
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		left, err := c.getRefCommit(tx, repository, leftReference)
+		if err != nil {
+			return nil, fmt.Errorf("left reference: %w", err)
+		}
+		right, err := c.getRefCommit(tx, repository, rightReference)
+		if err != nil {
+			return nil, fmt.Errorf("right reference: %w", err)
+		}
+		base, err := getMergeBase(tx, left, right)
+		if err != nil {
+			return nil, err
+		}
+		var branchName string
+		if err := tx.Get(&branchName, `SELECT name FROM catalog_branches WHERE id=$1`, base.BranchID); err != nil {
+			return nil, fmt.Errorf("branch name: %w", err)
+		}
+		return MakeReference(branchName, base.CommitID), nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	return c.DiffCommits(ctx, repository, leftReference, res.(string), limit, after)
+}
+
+// getRefCommit returns the branch and commit of a commit reference
+func (c *cataloger) getRefCommit(tx db.Tx, repository, reference string) (lineageCommit, error) {
+	ref, err := ParseRef(reference)
+	if err != nil {
+		return lineageCommit{}, err
+	}
+	branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+	if err != nil {
+		return lineageCommit{}, err
+	}
+	return lineageCommit{BranchID: branchID, CommitID: ref.CommitID}, nil
+}
+
+// getMergeBase returns the latest commit that is an ancestor of both left and right, following all parents of
+// each commit.  Commit IDs grow with time, so no other common ancestor descends from the one returned.
+func getMergeBase(tx db.Tx, left, right lineageCommit) (lineageCommit, error) {
+	var base lineageCommit
+	err := tx.Get(&base, `WITH RECURSIVE
+			left_ancestors(branch_id, commit_id) AS (
+				SELECT $1::bigint, $2::bigint
+				UNION
+				SELECT p.branch_id, p.commit_id FROM left_ancestors a
+					JOIN catalog_commits c ON c.branch_id = a.branch_id AND c.commit_id = a.commit_id
+					CROSS JOIN LATERAL (VALUES (c.branch_id, c.previous_commit_id), (c.merge_source_branch, c.merge_source_commit))
+						AS p(branch_id, commit_id)
+					WHERE p.branch_id IS NOT NULL AND p.commit_id > 0),
+			right_ancestors(branch_id, commit_id) AS (
+				SELECT $3::bigint, $4::bigint
+				UNION
+				SELECT p.branch_id, p.commit_id FROM right_ancestors a
+					JOIN catalog_commits c ON c.branch_id = a.branch_id AND c.commit_id = a.commit_id
+					CROSS JOIN LATERAL (VALUES (c.branch_id, c.previous_commit_id), (c.merge_source_branch, c.merge_source_commit))
+						AS p(branch_id, commit_id)
+					WHERE p.branch_id IS NOT NULL AND p.commit_id > 0)
+		SELECT branch_id, commit_id FROM left_ancestors
+		INTERSECT
+		SELECT branch_id, commit_id FROM right_ancestors
+		ORDER BY commit_id DESC
+		LIMIT 1`, left.BranchID, left.CommitID, right.BranchID, right.CommitID)
+	if errors.Is(err, db.ErrNotFound) {
+		return base, fmt.Errorf("merge base: %w", ErrCommitNotFound)
+	}
+	if err != nil {
+		return base, fmt.Errorf("merge base: %w", err)
+	}
+	return base, nil
+}
@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DiffRefs(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	// destination advances after the branch diverged
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "master commit", "tester", nil)
+	testutil.MustDo(t, "master commit", err)
+
+	testutil.MustDo(t, "delete file0", c.DeleteEntry(ctx, repository, "branch1", "/file0"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file3", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "branch commit", "tester", nil)
+	testutil.MustDo(t, "branch commit", err)
+
+	branchChanges := Differences{
+		Difference{Type: DifferenceTypeRemoved, Path: "/file0"},
+		Difference{Type: DifferenceTypeAdded, Path: "/file3"},
+	}
+	differences, _, err := c.DiffRefs(ctx, repository, "branch1", "master", -1, "")
+	testutil.MustDo(t, "diff refs", err)
+	if diff := deep.Equal(differences, branchChanges); diff != nil {
+		t.Fatal("DiffRefs() diverged branch", diff)
+	}
+
+	// merging the destination into the branch moves the merge base
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	testutil.MustDo(t, "merge master into branch1", err)
+	differences, _, err = c.DiffRefs(ctx, repository, "branch1", "master", -1, "")
+	testutil.MustDo(t, "diff refs after merge", err)
+	if diff := deep.Equal(differences, branchChanges); diff != nil {
+		t.Fatal("DiffRefs() after merge", diff)
+	}
+
+	// master is an ancestor of branch1, it introduces nothing
+	differences, _, err = c.DiffRefs(ctx, repository, "master", "branch1", -1, "")
+	testutil.MustDo(t, "diff refs of ancestor", err)
+	if len(differences) != 0 {
+		t.Fatalf("DiffRefs() of ancestor = %v, expected no differences", differences)
+	}
+}
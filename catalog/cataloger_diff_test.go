@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 
@@ -178,3 +179,41 @@ func TestCataloger_Diff_FromChildThreeBranches(t *testing.T) {
 		{Path: "/file8"},
 	})
 }
+
+func TestCataloger_Diff_MissingBranch(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	_, _, err := c.Diff(ctx, repository, "nobranch", "master", -1, "")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Fatalf("Diff err = %s, expected %s", err, ErrBranchNotFound)
+	}
+	_, _, err = c.Diff(ctx, repository, "master", "nobranch", -1, "")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Fatalf("Diff err = %s, expected %s", err, ErrBranchNotFound)
+	}
+}
+
+func TestCataloger_Diff_Tag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1.0", commitLog.Reference))
+	testutil.MustDo(t, "create tag named as branch", c.CreateTag(ctx, repository, "release", commitLog.Reference))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "commit file2", "tester", nil)
+	testutil.MustDo(t, "commit file2", err)
+
+	expected := Differences{{Type: DifferenceTypeAdded, Path: "file2"}}
+	for _, tag := range []string{"v1.0", "release"} {
+		differences, _, err := c.Diff(ctx, repository, "master", tag, -1, "")
+		testutil.MustDo(t, "diff branch with tag "+tag, err)
+		if diff := deep.Equal(differences, expected); diff != nil {
+			t.Fatalf("Diff(master, %s) differences: %s", tag, diff)
+		}
+	}
+}
@@ -3,16 +3,17 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) DiffUncommitted(ctx context.Context, repository, branch string, limit int, after string) (Differences, bool, error) {
+func (c *cataloger) DiffUncommitted(ctx context.Context, repository, branch string, prefix string, limit int, after string) (Differences, bool, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return nil, false, err
 	}
@@ -20,43 +21,61 @@ func (c *cataloger) DiffUncommitted(ctx context.Context, repository, branch stri
 	if limit < 0 || limit > DiffMaxLimit {
 		limit = DiffMaxLimit
 	}
+	start := time.Now()
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := c.getBranchIDCache(tx, repository, branch)
 		if err != nil {
 			return nil, err
 		}
-
-		lineage, err := getLineage(tx, branchID, CommittedID)
-		if err != nil {
-			return nil, fmt.Errorf("get lineage: %w", err)
-		}
-
-		q := psql.Select("CASE WHEN e.max_commit=0 THEN 1 WHEN v.path IS NOT NULL THEN 2 ELSE 0 END AS diff_type", "e.path").
-			FromSelect(sqEntriesV(UncommittedID), "e").
-			JoinClause(
-				sqEntriesLineageV(branchID, CommittedID, lineage).
-					Prefix("LEFT JOIN (").Suffix(") AS v ON v.path=e.path")).
-			Where(sq.And{
-				sq.Eq{"e.branch_id": branchID, "e.is_committed": false},
-				sq.Gt{"e.path": after},
-			}).
-			Limit(uint64(limit + 1)).
-			OrderBy("path")
-		sql, args, err := q.ToSql()
-		if err != nil {
-			return nil, fmt.Errorf("build sql: %w", err)
-		}
-
-		var result Differences
-		if err := tx.Select(&result, sql, args...); err != nil {
-			return nil, err
-		}
-		return result, nil
+		return getUncommittedDifferences(tx, branchID, prefix, limit+1, after)
 	}, c.txOpts(ctx, db.ReadOnly())...)
 	if err != nil {
 		return nil, false, err
 	}
 	differences := res.(Differences)
 	hasMore := paginateSlice(&differences, limit)
+	c.metrics.RecordDiff(DiffOperationUncommitted, time.Since(start), len(differences))
 	return differences, hasMore, nil
 }
+
+// getUncommittedDifferences returns up to limit uncommitted changes of branchID under prefix, starting after path
+// after
+func getUncommittedDifferences(tx db.Tx, branchID int64, prefix string, limit int, after string) (Differences, error) {
+	lineage, err := getLineage(tx, branchID, CommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("get lineage: %w", err)
+	}
+
+	q := psql.Select("*").
+		FromSelect(sqDiffUncommittedV(branchID, lineage, prefix), "d").
+		Where(sq.Gt{"path": after}).
+		Limit(uint64(limit)).
+		OrderBy("path")
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+
+	var result Differences
+	if err := tx.Select(&result, sql, args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sqDiffUncommittedV selects the uncommitted changes of branchID under prefix, compared with the branch's last commit
+func sqDiffUncommittedV(branchID int64, lineage []lineageCommit, prefix string) sq.SelectBuilder {
+	return sq.Select("CASE WHEN e.max_commit=0 THEN 1 WHEN v.path IS NOT NULL THEN 2 ELSE 0 END AS diff_type", "e.path",
+		"CASE WHEN e.max_commit=0 THEN COALESCE(v.size, 0) ELSE e.size END AS size",
+		"CASE WHEN e.max_commit=0 THEN COALESCE(v.creation_date, e.creation_date) ELSE e.creation_date END AS creation_date").
+		FromSelect(sqEntriesV(UncommittedID), "e").
+		JoinClause(
+			sqEntriesLineageV(branchID, CommittedID, lineage).
+				Prefix("LEFT JOIN (").Suffix(") AS v ON v.path=e.path")).
+		Where(sq.And{
+			sq.Eq{"e.branch_id": branchID, "e.is_committed": false},
+			sq.Like{"e.path": db.Prefix(prefix)},
+			// an uncommitted object with the committed content and metadata is not a change, no matter how it got there
+			sq.Expr("NOT (e.max_commit<>0 AND v.path IS NOT NULL AND NOT v.is_deleted AND " + sqSameContent("v", "e") + " AND v.metadata IS NOT DISTINCT FROM e.metadata)"),
+		})
+}
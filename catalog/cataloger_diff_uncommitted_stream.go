@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// DiffUncommittedStream calls fn with each uncommitted change of branch under prefix, in path order, as it is read
+// from the database.  It stops and returns the error when fn returns one.
+func (c *cataloger) DiffUncommittedStream(ctx context.Context, repository, branch string, prefix string, fn func(Difference) error) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	var entries int
+	// a read only repeatable read transaction fails no serialization and is not retried, calling fn once per change
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		lineage, err := getLineage(tx, branchID, CommittedID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+
+		q, args, err := psql.Select("*").
+			FromSelect(sqDiffUncommittedV(branchID, lineage, prefix), "d").
+			OrderBy("path").
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var difference Difference
+			if err := rows.StructScan(&difference); err != nil {
+				return nil, fmt.Errorf("scan difference: %w", err)
+			}
+			if err := fn(difference); err != nil {
+				return nil, err
+			}
+			entries++
+		}
+		return nil, rows.Err()
+	}, c.txOpts(ctx, db.ReadOnly(), db.WithIsolationLevel(sql.LevelRepeatableRead))...)
+	if err != nil {
+		return err
+	}
+	c.metrics.RecordDiff(DiffOperationUncommittedStream, time.Since(start), entries)
+	return nil
+}
@@ -0,0 +1,50 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DiffUncommittedStream(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 10; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	expected, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+
+	var differences Differences
+	err = c.DiffUncommittedStream(ctx, repository, "master", "", func(difference Difference) error {
+		differences = append(differences, difference)
+		return nil
+	})
+	testutil.MustDo(t, "diff uncommitted stream", err)
+	if diff := deep.Equal(differences, expected); diff != nil {
+		t.Fatal("DiffUncommittedStream() diff found", diff)
+	}
+
+	// stop early
+	errStop := errors.New("stop")
+	const stopAfter = 3
+	var calls int
+	err = c.DiffUncommittedStream(ctx, repository, "master", "", func(Difference) error {
+		calls++
+		if calls == stopAfter {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("DiffUncommittedStream() err = %v, expected %s", err, errStop)
+	}
+	if calls != stopAfter {
+		t.Fatalf("DiffUncommittedStream() called fn %d times, expected %d", calls, stopAfter)
+	}
+}
@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) DiffUncommittedSummary(ctx context.Context, repository, branch string) (map[DifferenceType]int, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}); err != nil {
+		return nil, err
+	}
+
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		return getUncommittedSummary(tx, branchID)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(map[DifferenceType]int), nil
+}
+
+// getUncommittedSummary counts the uncommitted changes of branchID by DifferenceType
+func getUncommittedSummary(tx db.Tx, branchID int64) (map[DifferenceType]int, error) {
+	lineage, err := getLineage(tx, branchID, CommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("get lineage: %w", err)
+	}
+
+	sql, args, err := psql.Select("diff_type", "count(*) AS count").
+		FromSelect(sqDiffUncommittedV(branchID, lineage, ""), "d").
+		GroupBy("diff_type").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+	var results []struct {
+		DiffType int `db:"diff_type"`
+		Count    int `db:"count"`
+	}
+	if err := tx.Select(&results, sql, args...); err != nil {
+		return nil, err
+	}
+	m := make(map[DifferenceType]int, len(results))
+	for _, res := range results {
+		m[DifferenceType(res.DiffType)] = res.Count
+	}
+	return m, nil
+}
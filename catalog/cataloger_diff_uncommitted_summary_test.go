@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DiffUncommittedSummary(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// no changes
+	summary, err := c.DiffUncommittedSummary(ctx, repository, "master")
+	testutil.MustDo(t, "diff uncommitted summary", err)
+	if len(summary) != 0 {
+		t.Fatalf("DiffUncommittedSummary() = %v, expected no changes", summary)
+	}
+
+	// commit files
+	for i := 0; i < 5; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err = c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	// delete two, change one (and re-upload one as is), add three
+	for _, p := range []string{"/file0", "/file1"} {
+		testutil.MustDo(t, "delete committed file on master",
+			c.DeleteEntry(ctx, repository, "master", p))
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+	for i := 5; i < 8; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+
+	summary, err = c.DiffUncommittedSummary(ctx, repository, "master")
+	testutil.MustDo(t, "diff uncommitted summary", err)
+	expected := map[DifferenceType]int{
+		DifferenceTypeRemoved: 2,
+		DifferenceTypeChanged: 1,
+		DifferenceTypeAdded:   3,
+	}
+	if diff := deep.Equal(summary, expected); diff != nil {
+		t.Fatal("DiffUncommittedSummary", diff)
+	}
+}
@@ -2,9 +2,12 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/davecgh/go-spew/spew"
 	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/testutil"
 )
@@ -25,7 +28,7 @@ func TestCataloger_DiffUncommitted_Pagination(t *testing.T) {
 	var differences Differences
 	var after string
 	for {
-		res, hasMore, err := c.DiffUncommitted(ctx, repository, "master", changesPerPage, after)
+		res, hasMore, err := c.DiffUncommitted(ctx, repository, "master", "", changesPerPage, after)
 		testutil.MustDo(t, "diff uncommitted changes", err)
 		if err != nil {
 			t.Fatalf("DiffUncommitted err=%s, expected none", err)
@@ -39,12 +42,12 @@ func TestCataloger_DiffUncommitted_Pagination(t *testing.T) {
 		}
 		after = res[len(res)-1].Path
 	}
-	if diff := deep.Equal(differences, expectedDifferences); diff != nil {
+	if diff := deep.Equal(testDifferencesTypeAndPath(differences), expectedDifferences); diff != nil {
 		t.Fatal("DiffUncommitted", diff)
 	}
 
 	// check the case where we ask for 0 amount
-	res, hasMore, err := c.DiffUncommitted(ctx, repository, "master", 0, "")
+	res, hasMore, err := c.DiffUncommitted(ctx, repository, "master", "", 0, "")
 	testutil.MustDo(t, "diff uncommitted with 0 limit", err)
 	if !hasMore {
 		t.Error("DiffUncommitted() has more should be true")
@@ -77,7 +80,7 @@ func TestCataloger_DiffUncommitted_Changes(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", overFilename, nil, "seed1")
 
 	// verify that diff uncommitted show the above change
-	differences, _, err := c.DiffUncommitted(ctx, repository, "master", -1, "")
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
 	if err != nil {
 		t.Fatalf("DiffUncommitted err = %s, expected none", err)
 	}
@@ -87,7 +90,7 @@ func TestCataloger_DiffUncommitted_Changes(t *testing.T) {
 		Difference{Type: DifferenceTypeChanged, Path: "/file2"},
 		Difference{Type: DifferenceTypeAdded, Path: "/file5"},
 	}
-	if diff := deep.Equal(differences, changes); diff != nil {
+	if diff := deep.Equal(testDifferencesTypeAndPath(differences), changes); diff != nil {
 		t.Fatal("DiffUncommitted", diff)
 	}
 }
@@ -105,7 +108,7 @@ func TestCataloger_DiffUncommitted_NoChance(t *testing.T) {
 	testutil.MustDo(t, "commit to master", err)
 
 	// verify that diff uncommitted show the above change
-	differences, hasMore, err := c.DiffUncommitted(ctx, repository, "master", -1, "")
+	differences, hasMore, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
 	if err != nil {
 		t.Fatalf("DiffUncommitted err = %s, expected none", err)
 	}
@@ -117,3 +120,327 @@ func TestCataloger_DiffUncommitted_NoChance(t *testing.T) {
 		t.Fatal("DiffUncommitted hadMore is true, expected false")
 	}
 }
+
+func TestCataloger_DiffUncommitted_Prefix(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// commit files
+	for _, p := range []string{"/dir1/file1", "/dir2/file1"} {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	// changes in and out of the prefix
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/dir1/file2", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/dir1/sub/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/dir2/file2", nil, "")
+	testutil.MustDo(t, "delete committed file on master",
+		c.DeleteEntry(ctx, repository, "master", "/dir1/file1"))
+	testutil.MustDo(t, "delete committed file on master",
+		c.DeleteEntry(ctx, repository, "master", "/dir2/file1"))
+
+	differences, hasMore, err := c.DiffUncommitted(ctx, repository, "master", "/dir1/", -1, "")
+	if err != nil {
+		t.Fatalf("DiffUncommitted err = %s, expected none", err)
+	}
+	if hasMore {
+		t.Fatal("DiffUncommitted hadMore is true, expected false")
+	}
+	changes := Differences{
+		Difference{Type: DifferenceTypeRemoved, Path: "/dir1/file1"},
+		Difference{Type: DifferenceTypeAdded, Path: "/dir1/file2"},
+		Difference{Type: DifferenceTypeAdded, Path: "/dir1/sub/file3"},
+	}
+	if diff := deep.Equal(testDifferencesTypeAndPath(differences), changes); diff != nil {
+		t.Fatal("DiffUncommitted", diff)
+	}
+}
+
+func TestCataloger_DiffUncommitted_SameContent(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// commit files
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	// re-upload identical content
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	// upload, delete and re-upload identical content
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file on master",
+		c.DeleteEntry(ctx, repository, "master", "/file1"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	// delete and re-upload identical content
+	testutil.MustDo(t, "delete committed file on master",
+		c.DeleteEntry(ctx, repository, "master", "/file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+
+	differences, hasMore, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	if err != nil {
+		t.Fatalf("DiffUncommitted err = %s, expected none", err)
+	}
+	if len(differences) != 0 {
+		t.Fatalf("DiffUncommitted differences = %s, expected none", differences)
+	}
+	if hasMore {
+		t.Fatal("DiffUncommitted hadMore is true, expected false")
+	}
+}
+
+func TestCataloger_DiffUncommitted_ChecksumAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	const (
+		md5Checksum      = "5eb63bbbe01eeed093cb22bb8f5acdc3"
+		otherMD5Checksum = "7215ee9c7d9dc229d2921a40e899ec5f"
+		sha256Checksum   = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	)
+	createEntry := func(path, checksum, address string) {
+		t.Helper()
+		err := c.CreateEntry(ctx, repository, "master", Entry{
+			Path:            path,
+			Checksum:        checksum,
+			PhysicalAddress: address,
+			Size:            11,
+		}, CreateEntryParams{})
+		testutil.MustDo(t, "create entry "+path, err)
+	}
+	for _, path := range []string{"same-object", "other-object", "same-algorithm"} {
+		createEntry(path, md5Checksum, "address-"+path)
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit md5 checksums", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	before, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get branch reference", err)
+
+	// the same object checksummed by another algorithm is not a change, other objects are
+	createEntry("same-object", sha256Checksum, "address-same-object")
+	createEntry("other-object", sha256Checksum, "address-other")
+	createEntry("same-algorithm", otherMD5Checksum, "address-same-algorithm")
+	expected := Differences{
+		{Type: DifferenceTypeChanged, Path: "other-object"},
+		{Type: DifferenceTypeChanged, Path: "same-algorithm"},
+	}
+
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+	if !differences.Equal(expected) {
+		t.Errorf("DiffUncommitted differences = %s, expected %s", spew.Sdump(differences), spew.Sdump(expected))
+	}
+
+	commitLog, err := c.Commit(ctx, repository, "master", "commit sha256 checksums", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	differences, _, err = c.DiffCommits(ctx, repository, commitLog.Reference, before, -1, "")
+	testutil.MustDo(t, "diff commits", err)
+	if !differences.Equal(expected) {
+		t.Errorf("DiffCommits differences = %s, expected %s", spew.Sdump(differences), spew.Sdump(expected))
+	}
+}
+
+func TestCataloger_DiffUncommitted_MetadataChange(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", Metadata{"k": "v"}, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", Metadata{"k": "v"}, "")
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	// re-upload identical content with different metadata
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", Metadata{"k": "v2"}, "")
+	// re-upload identical content with the same metadata
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", Metadata{"k": "v"}, "")
+
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+	expected := Differences{{Type: DifferenceTypeChanged, Path: "/file0"}}
+	if !differences.Equal(expected) {
+		t.Fatalf("DiffUncommitted differences = %s, expected %s", differences, expected)
+	}
+}
+
+func TestCataloger_DiffUncommitted_ObjectDetails(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	removedEntry, err := c.GetEntry(ctx, repository, "master", "/file1", GetEntryParams{})
+	testutil.MustDo(t, "get committed entry", err)
+
+	testutil.MustDo(t, "delete committed file on master",
+		c.DeleteEntry(ctx, repository, "master", "/file1"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+	changedEntry, err := c.GetEntry(ctx, repository, "master", "/file2", GetEntryParams{})
+	testutil.MustDo(t, "get changed entry", err)
+	addedEntry, err := c.GetEntry(ctx, repository, "master", "/file3", GetEntryParams{})
+	testutil.MustDo(t, "get added entry", err)
+
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	if err != nil {
+		t.Fatalf("DiffUncommitted err = %s, expected none", err)
+	}
+	expected := []*Entry{removedEntry, changedEntry, addedEntry}
+	if len(differences) != len(expected) {
+		t.Fatalf("DiffUncommitted differences len=%d, expected %d", len(differences), len(expected))
+	}
+	for i, ent := range expected {
+		d := differences[i]
+		if d.Path != ent.Path {
+			t.Fatalf("DiffUncommitted difference %d path=%s, expected %s", i, d.Path, ent.Path)
+		}
+		if d.Size != ent.Size {
+			t.Errorf("DiffUncommitted difference %s size=%d, expected %d", d.Path, d.Size, ent.Size)
+		}
+		if !d.CreationDate.Equal(ent.CreationDate) {
+			t.Errorf("DiffUncommitted difference %s creation date=%s, expected %s", d.Path, d.CreationDate, ent.CreationDate)
+		}
+	}
+}
+
+// testDifferencesTypeAndPath returns differences with only the type and path set
+func testDifferencesTypeAndPath(differences Differences) Differences {
+	if differences == nil {
+		return nil
+	}
+	result := make(Differences, len(differences))
+	for i, d := range differences {
+		result[i] = Difference{Type: d.Type, Path: d.Path}
+	}
+	return result
+}
+
+func TestCataloger_DiffUncommitted_MissingBranch(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	_, _, err := c.DiffUncommitted(ctx, repository, "nobranch", "", -1, "")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Fatalf("DiffUncommitted err = %s, expected %s", err, ErrBranchNotFound)
+	}
+}
+
+func TestCataloger_DiffUncommitted_PrefixPagination(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// commit files in and out of the prefix
+	for i := 0; i < 6; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/dir/file"+strconv.Itoa(i), nil, "")
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/other/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	// delete, change and add under both prefixes
+	var expectedDifferences Differences
+	for _, dir := range []string{"/dir/", "/other/"} {
+		for i := 0; i < 6; i++ {
+			p := dir + "file" + strconv.Itoa(i)
+			switch i % 3 {
+			case 0:
+				testutil.MustDo(t, "delete committed file", c.DeleteEntry(ctx, repository, "master", p))
+				if dir == "/dir/" {
+					expectedDifferences = append(expectedDifferences, Difference{Type: DifferenceTypeRemoved, Path: p})
+				}
+			case 1:
+				testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "seed1")
+				if dir == "/dir/" {
+					expectedDifferences = append(expectedDifferences, Difference{Type: DifferenceTypeChanged, Path: p})
+				}
+			}
+		}
+		p := dir + "new"
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+		if dir == "/dir/" {
+			expectedDifferences = append(expectedDifferences, Difference{Type: DifferenceTypeAdded, Path: p})
+		}
+	}
+
+	const changesPerPage = 2
+	var differences Differences
+	var after string
+	for {
+		res, hasMore, err := c.DiffUncommitted(ctx, repository, "master", "/dir/", changesPerPage, after)
+		testutil.MustDo(t, "diff uncommitted changes", err)
+		if len(res) > changesPerPage {
+			t.Fatalf("DiffUncommitted() result length %d, expected equal or less than %d", len(res), changesPerPage)
+		}
+		differences = append(differences, res...)
+		if !hasMore {
+			break
+		}
+		after = res[len(res)-1].Path
+	}
+	if diff := deep.Equal(testDifferencesTypeAndPath(differences), expectedDifferences); diff != nil {
+		t.Fatal("DiffUncommitted", diff)
+	}
+}
+
+func TestCataloger_DiffUncommitted_Canceled(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err := c.DiffUncommitted(canceledCtx, repository, "master", "", -1, "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("DiffUncommitted err = %v, expected %s", err, context.Canceled)
+	}
+}
+
+type diffsRecorder struct {
+	operations []string
+	entries    []int
+}
+
+func (r *diffsRecorder) RecordDiff(operation string, _ time.Duration, entries int) {
+	r.operations = append(r.operations, operation)
+	r.entries = append(r.entries, entries)
+}
+
+func TestCataloger_DiffUncommitted_Metrics(t *testing.T) {
+	ctx := context.Background()
+	recorder := &diffsRecorder{}
+	c := testCataloger(t, WithMetricsRecorder(recorder))
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+
+	_, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted changes", err)
+	err = c.DiffUncommittedStream(ctx, repository, "master", "", func(Difference) error { return nil })
+	testutil.MustDo(t, "diff uncommitted changes stream", err)
+	// failed diffs are not recorded
+	_, _, err = c.DiffUncommitted(ctx, repository, "no-branch", "", -1, "")
+	if err == nil {
+		t.Fatal("DiffUncommitted() on missing branch expected to fail")
+	}
+
+	expectedOperations := []string{DiffOperationUncommitted, DiffOperationUncommittedStream}
+	if diff := deep.Equal(recorder.operations, expectedOperations); diff != nil {
+		t.Fatal("RecordDiff() operations", diff)
+	}
+	if diff := deep.Equal(recorder.entries, []int{2, 2}); diff != nil {
+		t.Fatal("RecordDiff() entries", diff)
+	}
+}
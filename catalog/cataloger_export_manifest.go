@@ -0,0 +1,82 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// ManifestEntry is a single line of an exported manifest
+type ManifestEntry struct {
+	Path            string `db:"path" json:"path"`
+	Size            int64  `db:"size" json:"size"`
+	Checksum        string `db:"checksum" json:"checksum"`
+	PhysicalAddress string `db:"physical_address" json:"physical_address"`
+}
+
+// ExportManifest writes the objects of the commit ref resolves to as newline-delimited JSON ManifestEntry lines,
+// sorted by path.  Entries are streamed from the database as they are read.  Expired objects are left out as
+// their data is no longer in storage.
+func (c *cataloger) ExportManifest(ctx context.Context, repository, ref string, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	return c.walkCommittedEntries(ctx, repository, ref, "", func(entry *ManifestEntry) error {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("write manifest: %w", err)
+		}
+		return nil
+	})
+}
+
+// walkCommittedEntries calls fn for each object under prefix in the commit ref resolves to, in path order.
+// Deleted and expired objects are skipped.  fn is called while entries are read, so the read runs in a repeatable
+// read transaction that is never retried on serialization errors, which would call fn again for the same entries.
+func (c *cataloger) walkCommittedEntries(ctx context.Context, repository, ref, prefix string, fn func(entry *ManifestEntry) error) error {
+	reference, err := c.ResolveRef(ctx, repository, ref)
+	if err != nil {
+		return err
+	}
+	commitRef, err := ParseRef(reference)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, commitRef.Branch)
+		if err != nil {
+			return nil, err
+		}
+		lineage, err := getLineage(tx, branchID, commitRef.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		query, args, err := psql.
+			Select("path", "size", "checksum", "physical_address").
+			FromSelect(sqEntriesLineage(branchID, commitRef.CommitID, lineage), "entries").
+			Where(sq.And{sq.Like{"path": db.Prefix(prefix)}, sq.Eq{"is_deleted": false, "is_expired": false}}).
+			OrderBy("path").
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("query entries: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var entry ManifestEntry
+			if err := rows.StructScan(&entry); err != nil {
+				return nil, fmt.Errorf("scan entry: %w", err)
+			}
+			if err := fn(&entry); err != nil {
+				return nil, err
+			}
+		}
+		return nil, rows.Err()
+	}, c.txOpts(ctx, db.ReadOnly(), db.WithIsolationLevel(sql.LevelRepeatableRead))...)
+	return err
+}
@@ -0,0 +1,53 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ExportManifest(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for _, p := range []string{"b/file2", "a/file1", "c", "deleted"} {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit files", "tester", nil)
+	testutil.MustDo(t, "commit files", err)
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, repository, "master", "deleted"))
+	_, err = c.Commit(ctx, repository, "master", "delete file", "tester", nil)
+	testutil.MustDo(t, "commit delete", err)
+	// uncommitted changes are not part of the manifest
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "uncommitted", nil, "")
+
+	var buf bytes.Buffer
+	testutil.MustDo(t, "export manifest", c.ExportManifest(ctx, repository, "master", &buf))
+
+	var manifest []ManifestEntry
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var entry ManifestEntry
+		testutil.MustDo(t, "decode manifest line", json.Unmarshal(scanner.Bytes(), &entry))
+		manifest = append(manifest, entry)
+	}
+	testutil.MustDo(t, "read manifest", scanner.Err())
+
+	var expected []ManifestEntry
+	for _, p := range []string{"a/file1", "b/file2", "c"} {
+		checksum := testCreateEntryCalcChecksum(p, "")
+		var size int64
+		for i := range checksum {
+			size += int64(checksum[i])
+		}
+		expected = append(expected, ManifestEntry{Path: p, Size: size, Checksum: checksum, PhysicalAddress: checksum})
+	}
+	if diff := deep.Equal(manifest, expected); diff != nil {
+		t.Fatal("ExportManifest() diff found", diff)
+	}
+}
@@ -0,0 +1,29 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/treeverse/lakefs/block"
+)
+
+// ExportSymlinkManifest writes the storage URIs of the objects under prefix in the commit ref resolves to, one
+// per line, sorted by path.  The output is the content of a symlink.txt manifest, letting query engines such as
+// Presto and Spark read a table at a commit directly from the underlying storage.
+func (c *cataloger) ExportSymlinkManifest(ctx context.Context, repository, ref, prefix string, w io.Writer) error {
+	repo, err := c.GetRepository(ctx, repository)
+	if err != nil {
+		return err
+	}
+	return c.walkCommittedEntries(ctx, repository, ref, prefix, func(entry *ManifestEntry) error {
+		qk, err := block.ResolveNamespace(repo.StorageNamespace, entry.PhysicalAddress)
+		if err != nil {
+			return fmt.Errorf("resolve %s: %w", entry.Path, err)
+		}
+		if _, err := fmt.Fprintln(w, qk.Format()); err != nil {
+			return fmt.Errorf("write manifest: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ExportSymlinkManifest(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for _, p := range []string{"tables/t1/part-1", "tables/t1/part-0", "tables/t2/part-0"} {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	testutil.MustDo(t, "create fully qualified entry", c.CreateEntry(ctx, repository, "master", Entry{
+		Path:            "tables/t1/part-2",
+		Checksum:        "ff",
+		PhysicalAddress: "s3://other-bucket/data/part-2",
+	}, CreateEntryParams{}))
+	_, err := c.Commit(ctx, repository, "master", "commit tables", "tester", nil)
+	testutil.MustDo(t, "commit tables", err)
+
+	tests := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{
+			name:   "table",
+			prefix: "tables/t1/",
+			expected: "s3://bucket/" + testCreateEntryCalcChecksum("tables/t1/part-0", "") + "\n" +
+				"s3://bucket/" + testCreateEntryCalcChecksum("tables/t1/part-1", "") + "\n" +
+				"s3://other-bucket/data/part-2\n",
+		},
+		{
+			name:     "other table",
+			prefix:   "tables/t2/",
+			expected: "s3://bucket/" + testCreateEntryCalcChecksum("tables/t2/part-0", "") + "\n",
+		},
+		{
+			name:     "no match",
+			prefix:   "tables/t3/",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := c.ExportSymlinkManifest(ctx, repository, "master", tt.prefix, &buf)
+			testutil.MustDo(t, "export symlink manifest", err)
+			if buf.String() != tt.expected {
+				t.Fatalf("ExportSymlinkManifest() = %q, expected %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 func (c *cataloger) GetBranchReference(ctx context.Context, repository, branch string) (string, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return "", err
 	}
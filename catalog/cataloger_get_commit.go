@@ -18,21 +18,15 @@ func (c *cataloger) GetCommit(ctx context.Context, repository, reference string)
 		return nil, err
 	}
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		ref, err := c.resolveTagRef(tx, repository, ref)
 		if err != nil {
 			return nil, err
 		}
-		query := `SELECT b.name as branch_name,c.commit_id,c.previous_commit_id,c.committer,c.message,c.creation_date,c.metadata,
-			COALESCE(bb.name,'') as merge_source_branch_name,COALESCE(c.merge_source_commit,0) as merge_source_commit
-			FROM catalog_commits c JOIN catalog_branches b ON b.id = c.branch_id 
-				LEFT JOIN catalog_branches bb ON bb.id = c.merge_source_branch
-			WHERE b.id=$1 AND c.commit_id=$2`
-		var rawCommit commitLogRaw
-		if err := tx.Get(&rawCommit, query, branchID, ref.CommitID); err != nil {
+		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		if err != nil {
 			return nil, err
 		}
-		commit := convertRawCommit(&rawCommit)
-		return commit, nil
+		return getCommit(tx, branchID, ref.CommitID)
 	}, c.txOpts(ctx, db.ReadOnly())...)
 	if err != nil {
 		return nil, err
@@ -40,6 +34,19 @@ func (c *cataloger) GetCommit(ctx context.Context, repository, reference string)
 	return res.(*CommitLog), err
 }
 
+func getCommit(tx db.Tx, branchID int64, commitID CommitID) (*CommitLog, error) {
+	query := `SELECT b.name as branch_name,c.commit_id,c.previous_commit_id,c.committer,c.message,c.creation_date,c.metadata,
+			COALESCE(bb.name,'') as merge_source_branch_name,COALESCE(c.merge_source_commit,0) as merge_source_commit
+			FROM catalog_commits c JOIN catalog_branches b ON b.id = c.branch_id 
+				LEFT JOIN catalog_branches bb ON bb.id = c.merge_source_branch
+			WHERE b.id=$1 AND c.commit_id=$2`
+	var rawCommit commitLogRaw
+	if err := tx.Get(&rawCommit, query, branchID, commitID); err != nil {
+		return nil, err
+	}
+	return convertRawCommit(&rawCommit), nil
+}
+
 func convertRawCommit(raw *commitLogRaw) *CommitLog {
 	c := &CommitLog{
 		Reference:    MakeReference(raw.BranchName, raw.CommitID),
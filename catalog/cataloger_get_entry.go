@@ -26,11 +26,9 @@ func (c *cataloger) GetEntry(ctx context.Context, repository, reference string,
 		return nil, err
 	}
 
-	var entry *Entry
-	if useEntryReadBatched {
-		entry, err = c.getEntryBatchMaybeExpired(ctx, repository, *ref, path)
-	} else {
-		entry, err = c.getEntryMaybeExpired(ctx, repository, *ref, path)
+	entry, err := c.readEntry(ctx, repository, *ref, path)
+	if tagRef, ok := c.retryWithTagRef(ctx, repository, ref, err); ok {
+		entry, err = c.readEntry(ctx, repository, *tagRef, path)
 	}
 	if !params.ReturnExpired && entry != nil && entry.Expired {
 		return entry, ErrExpired
@@ -38,6 +36,15 @@ func (c *cataloger) GetEntry(ctx context.Context, repository, reference string,
 	return entry, err
 }
 
+func (c *cataloger) readEntry(ctx context.Context, repository string, ref Ref, path string) (*Entry, error) {
+	return c.getCommittedEntryCache(repository, ref, path, func(repository string, ref Ref, path string) (*Entry, error) {
+		if useEntryReadBatched {
+			return c.getEntryBatchMaybeExpired(ctx, repository, ref, path)
+		}
+		return c.getEntryMaybeExpired(ctx, repository, ref, path)
+	})
+}
+
 func (c *cataloger) getEntryBatchMaybeExpired(ctx context.Context, repository string, ref Ref, path string) (*Entry, error) {
 	replyChan := make(chan readResponse, 1) // used for a single return status message.
 	// channel written to and closed by readEntriesBatch
@@ -75,7 +82,7 @@ func (c *cataloger) getEntryMaybeExpired(ctx context.Context, repository string,
 		}
 
 		sql, args, err := psql.
-			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "is_expired").
+			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "checksums", "content_type", "expires_at", "is_expired").
 			FromSelect(sqEntriesLineage(branchID, ref.CommitID, lineage), "entries").
 			Where(sq.Eq{"path": path, "is_deleted": false}).
 			ToSql()
@@ -2,7 +2,11 @@ package catalog
 
 import (
 	"context"
+	"strconv"
 	"testing"
+
+	"github.com/treeverse/lakefs/catalog/params"
+	"github.com/treeverse/lakefs/testutil"
 )
 
 func TestCataloger_GetEntry(t *testing.T) {
@@ -107,6 +111,59 @@ func TestCataloger_GetEntry(t *testing.T) {
 	}
 }
 
+func TestCataloger_GetEntry_CommittedCache(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t, WithParams(params.Catalog{
+		Cache: params.Cache{Enabled: true, EntriesSize: 10},
+	}))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+
+	for i := 0; i < 2; i++ {
+		entry, err := c.GetEntry(ctx, repository, commitLog.Reference, "file1", GetEntryParams{})
+		testutil.MustDo(t, "get committed entry", err)
+		if entry.Path != "file1" {
+			t.Fatalf("GetEntry() read %d = %+v, expected file1", i, entry)
+		}
+		// modifying a returned entry must not modify the cached entry
+		entry.Path = "modified"
+	}
+	if _, err := c.GetEntry(ctx, repository, commitLog.Reference, "file2", GetEntryParams{}); err == nil {
+		t.Fatal("GetEntry() of entry created after commit expected error")
+	}
+
+	// reads of the branch are not cached
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, repository, "master", "file1"))
+	if _, err := c.GetEntry(ctx, repository, "master", "file1", GetEntryParams{}); err == nil {
+		t.Fatal("GetEntry() of deleted entry expected error")
+	}
+	if _, err := c.GetEntry(ctx, repository, commitLog.Reference, "file1", GetEntryParams{}); err != nil {
+		t.Fatalf("GetEntry() at commit of entry deleted after commit err = %s", err)
+	}
+}
+
+func BenchmarkCataloger_GetEntry_Committed(b *testing.B) {
+	ctx := context.Background()
+	for _, entriesSize := range []int{0, 10} {
+		b.Run("entries_size_"+strconv.Itoa(entriesSize), func(b *testing.B) {
+			c := testCataloger(b, WithParams(params.Catalog{
+				Cache: params.Cache{Enabled: true, EntriesSize: entriesSize},
+			}))
+			repository := testCatalogerRepo(b, ctx, c, "repository", "master")
+			testCatalogerCreateEntry(b, ctx, c, repository, "master", "file1", nil, "")
+			commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+			testutil.MustDo(b, "commit", err)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.GetEntry(ctx, repository, commitLog.Reference, "file1", GetEntryParams{})
+			}
+		})
+	}
+}
+
 func setupReadEntryData(t *testing.T, ctx context.Context, c Cataloger) string {
 	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
 	if err := c.CreateEntry(ctx, repository, "master", Entry{
@@ -21,9 +21,9 @@ func (c *cataloger) GetMultipartUpload(ctx context.Context, repository string, u
 		}
 		var m MultipartUpload
 		if err := tx.Get(&m, `
-			SELECT r.name as repository, m.upload_id, m.path, m.creation_date, m.physical_address 
+			SELECT r.name as repository, m.upload_id, m.path, m.creation_date, m.physical_address, m.metadata, m.content_type
 			FROM catalog_multipart_uploads m, catalog_repositories r
-			WHERE r.id = m.repository_id AND m.repository_id = $1 AND m.upload_id = $2`,
+			WHERE r.id = m.repository_id AND m.repository_id = $1 AND m.upload_id = $2 AND r.deleted_at IS NULL`,
 			repoID, uploadID); err != nil {
 			return nil, err
 		}
@@ -16,7 +16,10 @@ func TestCataloger_GetMultipartUpload(t *testing.T) {
 	if err := c.CreateRepository(ctx, "repo1", "s3://bucket1", "master"); err != nil {
 		t.Fatal("create repository for testing failed", err)
 	}
-	if err := c.CreateMultipartUpload(ctx, "repo1", "upload1", "/path1", "/file1", creationTime); err != nil {
+	if err := c.CreateMultipartUpload(ctx, "repo1", "upload1", "/path1", "/file1", creationTime, CreateMultipartUploadParams{
+		Metadata:    Metadata{"key": "value"},
+		ContentType: "text/plain",
+	}); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
@@ -39,6 +42,8 @@ func TestCataloger_GetMultipartUpload(t *testing.T) {
 				Path:            "/path1",
 				CreationDate:    creationTime,
 				PhysicalAddress: "/file1",
+				Metadata:        Metadata{"key": "value"},
+				ContentType:     "text/plain",
 			},
 			wantErr: false,
 		},
@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// GetPhysicalAddress returns the physical address and checksum of the object at path in ref, letting readers
+// access the underlying blob directly.  ref can be any reference accepted by ResolveRef, branches include
+// uncommitted changes.  Returns ErrEntryNotFound if path has no object in ref and ErrExpired if the object
+// expired from storage.
+func (c *cataloger) GetPhysicalAddress(ctx context.Context, repository, ref, path string) (string, string, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "path", IsValid: ValidatePathLimits(path, c.Path)},
+	}); err != nil {
+		return "", "", err
+	}
+	reference, err := c.resolveRefAncestry(ctx, repository, ref)
+	if err != nil {
+		return "", "", err
+	}
+	entry, err := c.GetEntry(ctx, repository, reference, path, GetEntryParams{})
+	if errors.Is(err, db.ErrNotFound) && !errors.Is(err, ErrBranchNotFound) && !errors.Is(err, ErrRepositoryNotFound) {
+		return "", "", ErrEntryNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return entry.PhysicalAddress, entry.Checksum, nil
+}
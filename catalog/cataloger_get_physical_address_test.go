@@ -0,0 +1,62 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_GetPhysicalAddress(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "v2")
+	_, err = c.Commit(ctx, repository, "master", "commit file1 v2", "tester", nil)
+	testutil.MustDo(t, "commit file1 v2", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file2", nil, "")
+
+	tests := []struct {
+		name    string
+		ref     string
+		path    string
+		want    string
+		wantErr error
+	}{
+		{name: "committed", ref: "master", path: "file1", want: testCreateEntryCalcChecksum("file1", "v2")},
+		{name: "ancestor", ref: "master~1", path: "file1", want: testCreateEntryCalcChecksum("file1", "")},
+		{name: "same content on branch", ref: "branch1", path: "file1", want: testCreateEntryCalcChecksum("file1", "v2")},
+		{name: "uncommitted", ref: "branch1", path: "file2", want: testCreateEntryCalcChecksum("file2", "")},
+		{name: "missing", ref: "master", path: "file2", wantErr: ErrEntryNotFound},
+		{name: "unknown branch", ref: "unknown", path: "file1", wantErr: ErrBranchNotFound},
+		{name: "no path", ref: "master", path: "", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, checksum, err := c.GetPhysicalAddress(ctx, repository, tt.ref, tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPhysicalAddress() err = %v, expected %v", err, tt.wantErr)
+			}
+			// test entries use the checksum as their physical address
+			if address != tt.want || checksum != tt.want {
+				t.Fatalf("GetPhysicalAddress() = %s, %s, expected %s", address, checksum, tt.want)
+			}
+		})
+	}
+}
+
+func TestCataloger_GetPhysicalAddress_UnknownRepository(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+
+	_, _, err := c.GetPhysicalAddress(ctx, "unknown-repo", "master", "file1")
+	if !errors.Is(err, db.ErrNotFound) || errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("GetPhysicalAddress() err = %v, expected repository or branch not found", err)
+	}
+}
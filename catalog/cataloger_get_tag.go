@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// GetTag returns the commit tagged by tagName
+func (c *cataloger) GetTag(ctx context.Context, repository, tagName string) (*CommitLog, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "tagName", IsValid: ValidateTagName(tagName)},
+	}); err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		ref, err := getTagRef(tx, repository, tagName)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrTagNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		if err != nil {
+			return nil, err
+		}
+		return getCommit(tx, branchID, ref.CommitID)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*CommitLog), nil
+}
+
+// resolveTagRef returns the reference of the commit tagged by ref when ref is a plain name that does not name an
+// existing branch.  Branches take precedence over tags, any other ref is returned as is.
+func (c *cataloger) resolveTagRef(tx db.Tx, repository string, ref *Ref) (*Ref, error) {
+	if ref.CommitID != UncommittedID {
+		return ref, nil
+	}
+	_, err := c.getBranchIDCache(tx, repository, ref.Branch)
+	if !errors.Is(err, ErrBranchNotFound) {
+		return ref, nil
+	}
+	tagRef, err := getTagRef(tx, repository, ref.Branch)
+	if errors.Is(err, db.ErrNotFound) {
+		return ref, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tag: %w", err)
+	}
+	return tagRef, nil
+}
+
+// retryWithTagRef returns the tagged commit reference to retry a read that failed with ErrBranchNotFound for ref.
+// Returns false if ref does not name a tag.
+func (c *cataloger) retryWithTagRef(ctx context.Context, repository string, ref *Ref, err error) (*Ref, bool) {
+	if !errors.Is(err, ErrBranchNotFound) || ref.CommitID != UncommittedID {
+		return nil, false
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		return getTagRef(tx, repository, ref.Branch)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false
+	}
+	return res.(*Ref), true
+}
@@ -0,0 +1,37 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// HasUncommittedChanges returns true if branch has uncommitted entries, including uncommitted deletes.
+// It is cheaper than listing the uncommitted differences when only their existence matters.
+func (c *cataloger) HasUncommittedChanges(ctx context.Context, repository, branch string) (bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}); err != nil {
+		return false, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// share lock - answer for the branch state before or after a concurrent commit, not during one
+		branchID, err := getBranchID(tx, repository, branch, LockTypeShare)
+		if err != nil {
+			return nil, fmt.Errorf("branch id: %w", err)
+		}
+		return hasUncommittedChanges(tx, branchID)
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return false, err
+	}
+	return res.(bool), nil
+}
+
+func hasUncommittedChanges(tx db.Tx, branchID int64) (bool, error) {
+	var exists bool
+	err := tx.Get(&exists, `SELECT EXISTS (SELECT 1 FROM catalog_entries WHERE branch_id=$1 AND min_commit=0)`, branchID)
+	return exists, err
+}
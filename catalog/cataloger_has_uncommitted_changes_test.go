@@ -0,0 +1,53 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_HasUncommittedChanges(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	hasChanges := func(step string, expected bool) {
+		t.Helper()
+		got, err := c.HasUncommittedChanges(ctx, repository, "master")
+		testutil.MustDo(t, "has uncommitted changes "+step, err)
+		if got != expected {
+			t.Fatalf("HasUncommittedChanges() %s = %t, expected %t", step, got, expected)
+		}
+	}
+
+	hasChanges("new repository", false)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	hasChanges("after create", true)
+
+	_, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	hasChanges("after commit", false)
+
+	testutil.MustDo(t, "delete file1", c.DeleteEntry(ctx, repository, "master", "file1"))
+	hasChanges("after delete", true)
+
+	testutil.MustDo(t, "reset file1", c.ResetEntry(ctx, repository, "master", "file1"))
+	hasChanges("after reset", false)
+}
+
+func TestCataloger_HasUncommittedChanges_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	if _, err := c.HasUncommittedChanges(ctx, repository, "nobranch"); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("HasUncommittedChanges() err = %v, expected %s", err, db.ErrNotFound)
+	}
+	if _, err := c.HasUncommittedChanges(ctx, repository, ""); !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("HasUncommittedChanges() err = %v, expected %s", err, ErrInvalidValue)
+	}
+}
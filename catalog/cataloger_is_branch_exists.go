@@ -10,7 +10,7 @@ import (
 func (c *cataloger) BranchExists(ctx context.Context, repository, branch string) (bool, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return false, err
 	}
@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+type entryVersion struct {
+	SourceBranch    int64    `db:"source_branch"`
+	MinCommit       CommitID `db:"min_commit"`
+	PhysicalAddress string   `db:"physical_address"`
+	Checksum        string   `db:"checksum"`
+}
+
+// LastModifiedCommit returns the commit that last changed the object at path, as seen from ref resolved by
+// ResolveRef.  First parents are walked back from ref's commit for as long as they have the object with the same
+// checksum and address.  Commits of a branch that kept its own entry unchanged are skipped at once: the entry is
+// visible on the branch since the commit it was written at.
+func (c *cataloger) LastModifiedCommit(ctx context.Context, repository, ref, path string) (*CommitLog, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "path", IsValid: ValidatePathLimits(path, c.Path)},
+	}); err != nil {
+		return nil, err
+	}
+	reference, err := c.ResolveRef(ctx, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		commit, err := c.getRefCommit(tx, repository, reference)
+		if err != nil {
+			return nil, err
+		}
+		version, err := getEntryVersion(tx, commit, path)
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			return nil, ErrEntryNotFound
+		}
+		for {
+			if version.SourceBranch == commit.BranchID && version.MinCommit < commit.CommitID {
+				commit.CommitID = version.MinCommit
+			}
+			parentBranchID, parentCommitID, err := getCommitFirstParent(tx, commit.BranchID, commit.CommitID)
+			if errors.Is(err, ErrCommitNotFound) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("parent commit: %w", err)
+			}
+			parent := lineageCommit{BranchID: parentBranchID, CommitID: parentCommitID}
+			parentVersion, err := getEntryVersion(tx, parent, path)
+			if err != nil {
+				return nil, err
+			}
+			if parentVersion == nil ||
+				parentVersion.Checksum != version.Checksum || parentVersion.PhysicalAddress != version.PhysicalAddress {
+				break
+			}
+			commit, version = parent, parentVersion
+		}
+		return getCommit(tx, commit.BranchID, commit.CommitID)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*CommitLog), nil
+}
+
+// getEntryVersion returns the entry of path visible in commit, nil if there is none
+func getEntryVersion(tx db.Tx, commit lineageCommit, path string) (*entryVersion, error) {
+	lineage, err := getLineage(tx, commit.BranchID, commit.CommitID)
+	if err != nil {
+		return nil, fmt.Errorf("get lineage: %w", err)
+	}
+	query, args, err := psql.Select("source_branch", "min_commit", "physical_address", "checksum").
+		FromSelect(sqEntriesLineage(commit.BranchID, commit.CommitID, lineage), "entries").
+		Where(sq.And{sq.Eq{"path": path}, sq.Expr("NOT is_deleted")}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+	var version entryVersion
+	err = tx.Get(&version, query, args...)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get entry: %w", err)
+	}
+	return &version, nil
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_LastModifiedCommit(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	commit := func(branch, message string) string {
+		t.Helper()
+		commitLog, err := c.Commit(ctx, repository, branch, message, "tester", nil)
+		testutil.MustDo(t, "commit "+message, err)
+		return commitLog.Reference
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	addReference := commit("master", "add")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "changed")
+	changeReference := commit("master", "change file1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file3", nil, "")
+	// rewriting the same content is not a change
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "changed")
+	commit("master", "add file3")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "file2"))
+	commit("master", "delete file2")
+	testCatalogerBranch(t, ctx, c, repository, "feature", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature", "file4", nil, "")
+	commit("feature", "add file4")
+
+	tests := []struct {
+		name          string
+		ref           string
+		path          string
+		wantReference string
+		wantErr       error
+	}{
+		{name: "changed", ref: "master", path: "file1", wantReference: changeReference},
+		{name: "from child branch", ref: "feature", path: "file1", wantReference: changeReference},
+		{name: "older ref", ref: addReference, path: "file2", wantReference: addReference},
+		{name: "deleted", ref: "master", path: "file2", wantErr: ErrEntryNotFound},
+		{name: "never existed", ref: "master", path: "file5", wantErr: ErrEntryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.LastModifiedCommit(ctx, repository, tt.ref, tt.path)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("LastModifiedCommit() err = %v, expected %s", err, tt.wantErr)
+				}
+				return
+			}
+			testutil.MustDo(t, "last modified commit", err)
+			if got.Reference != tt.wantReference {
+				t.Fatalf("LastModifiedCommit() reference %s, expected %s", got.Reference, tt.wantReference)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) ListBranchTips(ctx context.Context, repository string, limit int, after string) ([]*BranchTip, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return nil, false, err
+	}
+	if limit < 0 || limit > ListBranchesMaxLimit {
+		limit = ListBranchesMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+
+		// every branch is created with a commit, uncommitted changes are checked like hasUncommittedChanges
+		query := `SELECT b.name, c.commit_id, c.creation_date,
+				EXISTS (SELECT 1 FROM catalog_entries e WHERE e.branch_id=b.id AND e.min_commit=0) AS has_uncommitted_changes
+			FROM catalog_branches b
+			JOIN LATERAL (SELECT commit_id, creation_date FROM catalog_commits
+				WHERE branch_id=b.id ORDER BY commit_id DESC LIMIT 1) c ON true
+			WHERE b.repository_id = $1 AND b.name > $2
+			ORDER BY b.name
+			LIMIT $3`
+		var rawTips []*branchTipRaw
+		if err := tx.Select(&rawTips, query, repoID, after, limit+1); err != nil {
+			return nil, err
+		}
+		return rawTips, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	rawTips := res.([]*branchTipRaw)
+	hasMore := paginateSlice(&rawTips, limit)
+	tips := make([]*BranchTip, len(rawTips))
+	for i, raw := range rawTips {
+		tips[i] = &BranchTip{
+			Name:                  raw.Name,
+			Reference:             MakeReference(raw.Name, raw.CommitID),
+			CreationDate:          raw.CreationDate,
+			HasUncommittedChanges: raw.HasUncommittedChanges,
+		}
+	}
+	return tips, hasMore, nil
+}
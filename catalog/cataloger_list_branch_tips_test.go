@@ -0,0 +1,83 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListBranchTips(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for _, branch := range []string{"b1", "b2", "b3"} {
+		testCatalogerBranch(t, ctx, c, repository, branch, "master")
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "b2", "file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "b3", "file2", nil, "")
+	_, err := c.Commit(ctx, repository, "b3", "commit file2", "tester", nil)
+	testutil.MustDo(t, "commit file2", err)
+
+	type tipStatus struct {
+		Name                  string
+		Reference             string
+		HasUncommittedChanges bool
+	}
+	var expected []tipStatus
+	for _, branch := range []string{"b1", "b2", "b3", "master"} {
+		reference, err := c.GetBranchReference(ctx, repository, branch)
+		testutil.MustDo(t, "get branch reference", err)
+		expected = append(expected, tipStatus{Name: branch, Reference: reference, HasUncommittedChanges: branch == "b2"})
+	}
+
+	const tipsPerPage = 3
+	var got []tipStatus
+	var after string
+	for {
+		tips, hasMore, err := c.ListBranchTips(ctx, repository, tipsPerPage, after)
+		testutil.MustDo(t, "list branch tips", err)
+		if len(tips) > tipsPerPage {
+			t.Fatalf("ListBranchTips() result length %d, expected equal or less than %d", len(tips), tipsPerPage)
+		}
+		for _, tip := range tips {
+			if tip.CreationDate.IsZero() {
+				t.Errorf("ListBranchTips() branch %s has no commit creation date", tip.Name)
+			}
+			got = append(got, tipStatus{Name: tip.Name, Reference: tip.Reference, HasUncommittedChanges: tip.HasUncommittedChanges})
+		}
+		if !hasMore {
+			break
+		}
+		after = tips[len(tips)-1].Name
+	}
+	if diff := deep.Equal(got, expected); diff != nil {
+		t.Fatal("ListBranchTips()", diff)
+	}
+}
+
+func TestCataloger_ListBranchTips_EmptyRepository(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	tips, hasMore, err := c.ListBranchTips(ctx, repository, -1, "")
+	testutil.MustDo(t, "list branch tips", err)
+	if hasMore {
+		t.Error("ListBranchTips() has more should be false")
+	}
+	if len(tips) != 1 || tips[0].Name != "master" || tips[0].HasUncommittedChanges {
+		t.Fatalf("ListBranchTips() = %+v, expected only master without uncommitted changes", tips)
+	}
+
+	tips, hasMore, err = c.ListBranchTips(ctx, repository, -1, "master")
+	testutil.MustDo(t, "list branch tips after master", err)
+	if len(tips) != 0 || hasMore {
+		t.Fatalf("ListBranchTips() after last branch = %+v, %t, expected none", tips, hasMore)
+	}
+
+	if _, _, err := c.ListBranchTips(ctx, "no-repo", -1, ""); err == nil {
+		t.Fatal("ListBranchTips() of unknown repository expected to fail")
+	}
+}
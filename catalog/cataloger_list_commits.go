@@ -12,7 +12,7 @@ const ListCommitsMaxLimit = 10000
 func (c *cataloger) ListCommits(ctx context.Context, repository, branch string, fromReference string, limit int) ([]*CommitLog, bool, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 		{Name: "fromReference", IsValid: ValidateOptionalString(fromReference, IsValidReference)},
 	}); err != nil {
 		return nil, false, err
@@ -463,3 +463,42 @@ func TestCataloger_ListCommits_Order(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestCataloger_ListCommits_Paginate(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file"+strconv.Itoa(i), nil, "")
+		_, err := c.Commit(ctx, repository, "branch1", "commit"+strconv.Itoa(i), "tester", nil)
+		testutil.MustDo(t, "commit to branch1", err)
+	}
+	_, err := c.Merge(ctx, repository, "branch1", "master", "tester", "merge branch1", nil)
+	testutil.MustDo(t, "merge branch1 into master", err)
+
+	allCommits, hasMore, err := c.ListCommits(ctx, repository, "master", "", -1)
+	testutil.MustDo(t, "list all commits", err)
+	if hasMore {
+		t.Fatal("ListCommits() listing all commits has more")
+	}
+	if len(allCommits[0].Parents) != 2 {
+		t.Fatalf("ListCommits() merge commit parents = %v, expected 2", allCommits[0].Parents)
+	}
+
+	// page through the history using the last reference of each page
+	var pagedCommits []*CommitLog
+	var fromReference string
+	for {
+		commits, hasMore, err := c.ListCommits(ctx, repository, "master", fromReference, 2)
+		testutil.MustDo(t, "list commits page", err)
+		pagedCommits = append(pagedCommits, commits...)
+		if !hasMore {
+			break
+		}
+		fromReference = commits[len(commits)-1].Reference
+	}
+	if diff := deep.Equal(pagedCommits, allCommits); diff != nil {
+		t.Fatal("ListCommits() pages differ from listing all commits", diff)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/treeverse/lakefs/db"
@@ -32,14 +33,9 @@ func (c *cataloger) ListEntries(ctx context.Context, repository, reference strin
 		limit = ListEntriesMaxLimit
 	}
 
-	var res interface{}
-	switch delimiter {
-	case "":
-		res, err = c.listEntries(ctx, repository, ref, prefix, after, limit)
-	case DefaultPathDelimiter:
-		res, err = c.listEntriesByLevel(ctx, repository, ref, prefix, after, delimiter, limit)
-	default:
-		err = ErrUnsupportedDelimiter
+	res, err := c.listEntriesByDelimiter(ctx, repository, ref, prefix, after, delimiter, limit)
+	if tagRef, ok := c.retryWithTagRef(ctx, repository, ref, err); ok {
+		res, err = c.listEntriesByDelimiter(ctx, repository, tagRef, prefix, after, delimiter, limit)
 	}
 	if err != nil {
 		return nil, false, err
@@ -49,6 +45,17 @@ func (c *cataloger) ListEntries(ctx context.Context, repository, reference strin
 	return result, moreToRead, nil
 }
 
+func (c *cataloger) listEntriesByDelimiter(ctx context.Context, repository string, ref *Ref, prefix, after, delimiter string, limit int) (interface{}, error) {
+	switch delimiter {
+	case "":
+		return c.listEntries(ctx, repository, ref, prefix, after, limit)
+	case DefaultPathDelimiter:
+		return c.listEntriesByLevel(ctx, repository, ref, prefix, after, delimiter, limit)
+	default:
+		return nil, ErrUnsupportedDelimiter
+	}
+}
+
 func (c *cataloger) listEntries(ctx context.Context, repository string, ref *Ref, prefix string, after string, limit int) (interface{}, error) {
 	return c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
@@ -62,10 +69,10 @@ func (c *cataloger) listEntries(ctx context.Context, repository string, ref *Ref
 			return nil, fmt.Errorf("get lineage: %w", err)
 		}
 		entriesSQL, args, err := psql.
-			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata").
+			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "checksums", "content_type", "expires_at").
 			FromSelect(sqEntriesLineage(branchID, ref.CommitID, lineage), "entries").
-			// Listing also shows expired objects!
-			Where(sq.And{sq.Like{"path": likePath}, sq.Eq{"is_deleted": false}, sq.Gt{"path": after}}).
+			// Listing also shows expired objects!  Only entries past their lifecycle expiry are hidden.
+			Where(sq.And{sq.Like{"path": likePath}, sq.Eq{"is_deleted": false}, sq.Gt{"path": after}, sqNotLifecycleExpired(time.Now())}).
 			OrderBy("path").
 			Limit(uint64(limit) + 1).
 			ToSql()
@@ -92,11 +99,30 @@ func (c *cataloger) listEntriesByLevel(ctx context.Context, repository string, r
 		if err != nil {
 			return nil, fmt.Errorf("get lineage: %w", err)
 		}
-		markerList, err := loopByLevel(tx, prefix, after, delimiter, limit, ListEntriesBranchBatchSize, branchID, commitID, lineage)
-		if err != nil {
-			return nil, err
+		// entries past their lifecycle expiry are dropped after loading, keep reading levels until there are
+		// more than limit entries or nothing left to read
+		now := time.Now()
+		levelAfter := after
+		var entries []*Entry
+		for {
+			markerList, err := loopByLevel(tx, prefix, levelAfter, delimiter, limit, ListEntriesBranchBatchSize, branchID, commitID, lineage)
+			if err != nil {
+				return nil, err
+			}
+			levelEntries, err := loadEntriesIntoMarkerList(markerList, tx, branchID, commitID, lineage, delimiter, prefix)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range levelEntries {
+				if !entry.IsLifecycleExpired(now) {
+					entries = append(entries, entry)
+				}
+			}
+			if len(markerList) <= limit || len(entries) > limit {
+				return entries, nil
+			}
+			levelAfter = prefix + markerList[len(markerList)-1]
 		}
-		return loadEntriesIntoMarkerList(markerList, tx, branchID, commitID, lineage, delimiter, prefix)
 	}, c.txOpts(ctx, db.ReadOnly())...)
 }
 
@@ -389,7 +415,7 @@ func loadEntriesIntoMarkerList(markerList []string, tx db.Tx, branchID int64, co
 	entriesReader := sqEntriesLineageV(branchID, commitID, lineage)
 	for _, r := range entryRuns {
 		entriesSQL, args, err := sq.
-			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata").
+			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "checksums", "content_type", "expires_at").
 			Where("NOT is_deleted AND path between ? and ?", prefix+r.startEntryRun, prefix+r.endEntryRun).
 			FromSelect(entriesReader, "e").
 			PlaceholderFormat(sq.Dollar).
@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// ListExpiredEntries lists the entries visible on branch, uncommitted changes included, whose lifecycle expiry
+// is not after now, in path order after the path after.  These entries are already hidden from ListEntries,
+// deleting them removes them from the branch.
+func (c *cataloger) ListExpiredEntries(ctx context.Context, repository, branch string, now time.Time, limit int, after string) ([]*Entry, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}); err != nil {
+		return nil, false, err
+	}
+	if limit < 0 || limit > ListEntriesMaxLimit {
+		limit = ListEntriesMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		lineage, err := getLineage(tx, branchID, UncommittedID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		query, args, err := psql.
+			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "checksums", "content_type", "expires_at").
+			FromSelect(sqEntriesLineage(branchID, UncommittedID, lineage), "entries").
+			Where(sq.And{sq.Eq{"is_deleted": false}, sq.Gt{"path": after}, sq.LtOrEq{"expires_at": now}}).
+			OrderBy("path").
+			Limit(uint64(limit) + 1).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		var entries []*Entry
+		if err := tx.Select(&entries, query, args...); err != nil {
+			return nil, fmt.Errorf("select entries: %w", err)
+		}
+		return entries, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	entries := res.([]*Entry)
+	hasMore := paginateSlice(&entries, limit)
+	return entries, hasMore, nil
+}
+
+// IsLifecycleExpired returns true if the entry has a lifecycle expiry that is not after now
+func (e *Entry) IsLifecycleExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && !e.ExpiresAt.After(now)
+}
+
+// sqNotLifecycleExpired selects entries without a lifecycle expiry or with one after now
+func sqNotLifecycleExpired(now time.Time) sq.Sqlizer {
+	return sq.Or{sq.Eq{"expires_at": nil}, sq.Gt{"expires_at": now}}
+}
@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// timestamps are stored in microseconds
+	now := time.Now().Truncate(time.Second)
+	expiry := map[string]*time.Time{
+		"a/past":   timePtr(now.Add(-time.Hour)),
+		"a/at":     timePtr(now),
+		"a/future": timePtr(now.Add(time.Hour)),
+		"a/never":  nil,
+		"b/past":   timePtr(now.Add(-time.Minute)),
+	}
+	for path, expiresAt := range expiry {
+		checksum := testCreateEntryCalcChecksum(path, "")
+		err := c.CreateEntry(ctx, repository, "master", Entry{
+			Path:            path,
+			Checksum:        checksum,
+			PhysicalAddress: checksum,
+			Size:            1,
+			ExpiresAt:       expiresAt,
+		}, CreateEntryParams{})
+		testutil.MustDo(t, "create entry "+path, err)
+	}
+	_, err := c.Commit(ctx, repository, "master", "entries with expiry", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "b1", "master")
+
+	listExpired := func(branch string, now time.Time) []string {
+		const limit = 2
+		var paths []string
+		var after string
+		for {
+			entries, hasMore, err := c.ListExpiredEntries(ctx, repository, branch, now, limit, after)
+			testutil.MustDo(t, "list expired entries", err)
+			if len(entries) > limit {
+				t.Fatalf("ListExpiredEntries() result length %d, expected equal or less than %d", len(entries), limit)
+			}
+			for _, entry := range entries {
+				if entry.ExpiresAt == nil || entry.ExpiresAt.After(now) {
+					t.Errorf("ListExpiredEntries() entry %s expires at %v, after %s", entry.Path, entry.ExpiresAt, now)
+				}
+				paths = append(paths, entry.Path)
+			}
+			if !hasMore {
+				return paths
+			}
+			after = entries[len(entries)-1].Path
+		}
+	}
+	if diff := deep.Equal(listExpired("master", now), []string{"a/at", "a/past", "b/past"}); diff != nil {
+		t.Error("ListExpiredEntries() at expiry", diff)
+	}
+	if diff := deep.Equal(listExpired("master", now.Add(-time.Microsecond)), []string{"a/past", "b/past"}); diff != nil {
+		t.Error("ListExpiredEntries() before expiry", diff)
+	}
+	// child branch sees the expiry of entries it inherits
+	if diff := deep.Equal(listExpired("b1", now.Add(time.Hour)), []string{"a/at", "a/future", "a/past", "b/past"}); diff != nil {
+		t.Error("ListExpiredEntries() on child branch", diff)
+	}
+
+	// listings hide expired entries, by level pagination skips over them
+	entries, _, err := c.ListEntries(ctx, repository, "master", "", "", "", -1)
+	testutil.MustDo(t, "list entries", err)
+	if diff := deep.Equal(testEntriesPaths(entries), []string{"a/future", "a/never"}); diff != nil {
+		t.Error("ListEntries()", diff)
+	}
+	var paths []string
+	var after string
+	for {
+		entries, hasMore, err := c.ListEntries(ctx, repository, "master", "a/", after, DefaultPathDelimiter, 1)
+		testutil.MustDo(t, "list entries by level", err)
+		paths = append(paths, testEntriesPaths(entries)...)
+		if !hasMore {
+			break
+		}
+		after = entries[len(entries)-1].Path
+	}
+	if diff := deep.Equal(paths, []string{"a/future", "a/never"}); diff != nil {
+		t.Error("ListEntries() by level", diff)
+	}
+
+	// reads still return the entry with its expiry
+	entry, err := c.GetEntry(ctx, repository, "master", "a/past", GetEntryParams{})
+	testutil.MustDo(t, "get expired entry", err)
+	if entry.ExpiresAt == nil || !entry.ExpiresAt.Equal(*expiry["a/past"]) {
+		t.Errorf("GetEntry() expires at %v, expected %s", entry.ExpiresAt, expiry["a/past"])
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func testEntriesPaths(entries []*Entry) []string {
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.Path
+	}
+	return paths
+}
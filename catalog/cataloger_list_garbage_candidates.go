@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// ListGarbageCandidates returns the physical addresses of objects stored for repository before the given time
+// that no entry refers to.  Entries of every commit on every branch are kept in catalog_entries, so an object
+// reachable from any branch, tag or commit is never a candidate.  Objects of in-progress multipart uploads and
+// objects already marked for deletion by retention are left out.
+// Candidates are removed from deduplication, so later uploads of the same content are stored at a new address
+// rather than at one the caller deletes.  Each candidate is therefore returned only once.
+func (c *cataloger) ListGarbageCandidates(ctx context.Context, repository string, before time.Time) ([]string, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		// entries may refer to objects of another repository sharing the same storage, so references are
+		// checked across all repositories
+		var addresses []string
+		err = tx.Select(&addresses, `DELETE FROM catalog_object_dedup d
+			WHERE d.repository_id=$1 AND NOT d.deleting AND d.creation_date < $2
+				AND NOT EXISTS (SELECT 1 FROM catalog_entries e WHERE e.physical_address=d.physical_address)
+				AND NOT EXISTS (SELECT 1 FROM catalog_multipart_uploads u WHERE u.physical_address=d.physical_address)
+			RETURNING d.physical_address`, repoID, before)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(addresses)
+		return addresses, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return res.([]string), nil
+}
@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/db/params"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListGarbageCandidates(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	createEntry := func(path, physicalAddress, dedupID string) {
+		t.Helper()
+		testutil.MustDo(t, "create entry "+path, c.CreateEntry(ctx, repository, "master", Entry{
+			Path:            path,
+			PhysicalAddress: physicalAddress,
+			Checksum:        dedupID,
+		}, CreateEntryParams{Dedup: DedupParams{ID: dedupID, StorageNamespace: "s3://bucket"}}))
+	}
+	createEntry("object", "committed-address", "01")
+	createEntry("tagged", "tagged-address", "02")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit objects", "tester", nil)
+	testutil.MustDo(t, "commit objects", err)
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1", commitLog.Reference))
+	// deleted after the tagged commit, still reachable from it
+	testutil.MustDo(t, "delete tagged", c.DeleteEntry(ctx, repository, "master", "tagged"))
+	_, err = c.Commit(ctx, repository, "master", "delete tagged", "tester", nil)
+	testutil.MustDo(t, "commit delete", err)
+	// overwritten and reverted, no longer referenced
+	createEntry("object", "reverted-address", "03")
+
+	// dedup records are written in the background
+	conn, err := db.ConnectDB(params.Database{Driver: db.DatabaseDriver, ConnectionString: c.DbConnURI})
+	testutil.MustDo(t, "connect to database", err)
+	defer func() { _ = conn.Close() }()
+	waitForDedup := func(expected int) {
+		t.Helper()
+		for i := 0; ; i++ {
+			var count int
+			err := conn.Get(&count, `SELECT COUNT(*) FROM catalog_object_dedup d JOIN catalog_repositories r ON r.id = d.repository_id
+				WHERE r.name = $1`, repository)
+			testutil.MustDo(t, "count dedup records", err)
+			if count == expected {
+				break
+			}
+			if i == 30 {
+				t.Fatalf("timeout waiting for dedup records, found %d expected %d", count, expected)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	waitForDedup(3)
+	testutil.MustDo(t, "reset object", c.ResetEntry(ctx, repository, "master", "object"))
+
+	candidates, err := c.ListGarbageCandidates(ctx, repository, time.Now())
+	testutil.MustDo(t, "list garbage candidates", err)
+	if diff := deep.Equal(candidates, []string{"reverted-address"}); diff != nil {
+		t.Fatal("ListGarbageCandidates() diff found", diff)
+	}
+
+	candidates, err = c.ListGarbageCandidates(ctx, repository, time.Now().Add(-time.Hour))
+	testutil.MustDo(t, "list garbage candidates before cutoff", err)
+	if len(candidates) != 0 {
+		t.Fatalf("ListGarbageCandidates() before objects were stored = %v, expected none", candidates)
+	}
+	candidates, err = c.ListGarbageCandidates(ctx, repository, time.Now())
+	testutil.MustDo(t, "list garbage candidates again", err)
+	if len(candidates) != 0 {
+		t.Fatalf("ListGarbageCandidates() listed candidates again = %v, expected none", candidates)
+	}
+
+	// the content of a candidate uploaded again is not deduplicated onto the candidate address
+	createEntry("again", "new-address", "03")
+	waitForDedup(3)
+	entry, err := c.GetEntry(ctx, repository, "master", "again", GetEntryParams{})
+	testutil.MustDo(t, "get entry uploaded again", err)
+	if entry.PhysicalAddress != "new-address" {
+		t.Fatalf("entry uploaded again physical address = %s, expected new-address", entry.PhysicalAddress)
+	}
+}
@@ -0,0 +1,37 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// ListMultipartUploadParts returns the parts uploaded so far, ordered by part number
+func (c *cataloger) ListMultipartUploadParts(ctx context.Context, repository, uploadID string) ([]*MultipartUploadPart, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "uploadID", IsValid: ValidateUploadID(uploadID)},
+	}); err != nil {
+		return nil, err
+	}
+
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		if _, err := c.getMultipartUpload(tx, repository, uploadID, LockTypeNone); err != nil {
+			return nil, err
+		}
+		return getMultipartUploadParts(tx, uploadID)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*MultipartUploadPart), nil
+}
+
+func getMultipartUploadParts(tx db.Tx, uploadID string) ([]*MultipartUploadPart, error) {
+	var parts []*MultipartUploadPart
+	err := tx.Select(&parts, `SELECT part_number, etag, size, physical_address, creation_date
+			FROM catalog_multipart_upload_parts
+			WHERE upload_id = $1
+			ORDER BY part_number`, uploadID)
+	return parts, err
+}
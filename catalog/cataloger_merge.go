@@ -6,16 +6,25 @@ import (
 	"fmt"
 	"strconv"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/logging"
 )
 
+// MergeMaxConflicts is the number of conflicting paths reported by a merge
+const MergeMaxConflicts = DiffMaxLimit
+
+// Merge merges leftBranch into rightBranch.  Both sides must be branches: merging from a tag or a commit reference
+// returns ErrFeatureNotSupported.
 func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata Metadata) (*MergeResult, error) {
+	if !IsValidBranchName(leftBranch) && IsValidReference(leftBranch) {
+		return nil, fmt.Errorf("merge from %s: %w", leftBranch, ErrFeatureNotSupported)
+	}
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "leftBranch", IsValid: ValidateBranchName(leftBranch)},
-		{Name: "rightBranch", IsValid: ValidateBranchName(rightBranch)},
+		{Name: "leftBranch", Check: ValidateBranchName(leftBranch)},
+		{Name: "rightBranch", Check: ValidateBranchName(rightBranch)},
 		{Name: "committer", IsValid: ValidateCommitter(committer)},
 	}); err != nil {
 		return nil, err
@@ -24,6 +33,11 @@ func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBran
 	mergeResult := &MergeResult{}
 	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		leftID, err := getBranchID(tx, repository, leftBranch, LockTypeUpdate)
+		if errors.Is(err, db.ErrNotFound) {
+			if _, tagErr := getTagRef(tx, repository, leftBranch); tagErr == nil {
+				return nil, fmt.Errorf("merge from %s: %w", leftBranch, ErrFeatureNotSupported)
+			}
+		}
 		if err != nil {
 			return nil, fmt.Errorf("left branch: %w", err)
 		}
@@ -46,6 +60,10 @@ func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBran
 		}
 		// check for conflicts
 		if mergeResult.Summary[DifferenceTypeConflict] > 0 {
+			mergeResult.Conflicts, err = getDiffConflicts(tx, MergeMaxConflicts)
+			if err != nil {
+				return nil, err
+			}
 			return nil, ErrConflictFound
 		}
 		// check for changes
@@ -76,6 +94,24 @@ func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBran
 	return mergeResult, err
 }
 
+// getDiffConflicts returns the paths in conflict found by the last diff, in order
+func getDiffConflicts(tx db.Tx, limit int) ([]string, error) {
+	query, args, err := psql.Select("path").
+		From(diffResultsTableName).
+		Where(sq.Eq{"diff_type": DifferenceTypeConflict}).
+		OrderBy("path").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("format diff conflicts query: %w", err)
+	}
+	var paths []string
+	if err := tx.Select(&paths, query, args...); err != nil {
+		return nil, fmt.Errorf("select diff conflicts: %w", err)
+	}
+	return paths, nil
+}
+
 // hasCommitDifferences - Checks if the current commit id of target or source branch advanced since last merge
 func hasCommitDifferences(tx db.Tx, leftID, rightID int64) (bool, error) {
 	var hasCommitDifferences bool
@@ -140,8 +176,8 @@ func (c *cataloger) mergeFromParent(tx db.Tx, previousMaxCommitID, nextCommitID
 	}
 
 	// DifferenceTypeChanged - create entries into this commit based on parent branch
-	_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,creation_date,size,checksum,metadata,min_commit)
-				SELECT $1,path,physical_address,creation_date,size,checksum,metadata,$2 AS min_commit
+	_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,creation_date,size,checksum,metadata,checksums,content_type,expires_at,min_commit)
+				SELECT $1,path,physical_address,creation_date,size,checksum,metadata,checksums,content_type,expires_at,$2 AS min_commit
 				FROM catalog_entries e
 				WHERE e.ctid IN (SELECT d.entry_ctid FROM `+diffResultsTableName+` d WHERE d.diff_type=$3 
  				-- the or condition - diff will see an entry as new if it is deleted in child. but merge still need to copy it
@@ -188,8 +224,8 @@ func (c *cataloger) mergeFromChild(tx db.Tx, previousMaxCommitID, nextCommitID C
 	}
 
 	// DifferenceTypeChanged or DifferenceTypeAdded - create entries into this commit based on parent branch
-	_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,creation_date,size,checksum,metadata,min_commit)
-				SELECT $1,path,physical_address,creation_date,size,checksum,metadata,$2 AS min_commit
+	_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,creation_date,size,checksum,metadata,checksums,content_type,expires_at,min_commit)
+				SELECT $1,path,physical_address,creation_date,size,checksum,metadata,checksums,content_type,expires_at,$2 AS min_commit
 				FROM catalog_entries e
 				WHERE e.ctid IN (SELECT entry_ctid FROM `+diffResultsTableName+` WHERE diff_type IN ($3,$4))`,
 		parentID, nextCommitID, DifferenceTypeAdded, DifferenceTypeChanged)
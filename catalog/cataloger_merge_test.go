@@ -145,6 +145,9 @@ func TestCataloger_Merge_FromParentConflicts(t *testing.T) {
 	if res.Summary[DifferenceTypeConflict] != len(expectedDifferences) {
 		t.Fatalf("Merge summary conflicts=%d, expected %d", res.Summary[DifferenceTypeConflict], len(expectedDifferences))
 	}
+	if diff := deep.Equal(res.Conflicts, []string{"/file2", "/file5"}); diff != nil {
+		t.Errorf("Merge conflicts diff %s", diff)
+	}
 	differences, _, err := c.Diff(ctx, repository, "master", "branch1", -1, "")
 	testutil.MustDo(t, "diff merge changes", err)
 	if !differences.Equal(expectedDifferences) {
@@ -806,6 +809,9 @@ func TestCataloger_Merge_FromChildConflicts(t *testing.T) {
 	}); diff != nil {
 		t.Fatal("Merge Summary", diff)
 	}
+	if diff := deep.Equal(res.Conflicts, []string{"/file0"}); diff != nil {
+		t.Fatal("Merge Conflicts", diff)
+	}
 	// TODO(barak): enable test after diff between commits is supported
 	//expectedDifferences := Differences{
 	//	Difference{Type: DifferenceTypeConflict, Path: "/file0"},
@@ -1147,3 +1153,22 @@ func TestCataloger_MergeWitoutDiff(t *testing.T) {
 		t.Fatal("did not get 'nothing to commit' error")
 	}
 }
+
+func TestCataloger_Merge_FromTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1.0", commitLog.Reference))
+	testutil.MustDo(t, "create tag named as branch", c.CreateTag(ctx, repository, "release", commitLog.Reference))
+
+	for _, tag := range []string{"v1.0", "release"} {
+		_, err := c.Merge(ctx, repository, tag, "branch1", "tester", "", nil)
+		if !errors.Is(err, ErrFeatureNotSupported) {
+			t.Fatalf("Merge() from tag %s err = %v, expected %s", tag, err, ErrFeatureNotSupported)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// EntryFailure is an entry rejected by PutEntries, with its position in the entries passed
+type EntryFailure struct {
+	Pos  int
+	Path string
+	Err  error
+}
+
+func (f *EntryFailure) Error() string {
+	return fmt.Sprintf("entry at pos %d, path %s: %s", f.Pos, f.Path, f.Err)
+}
+
+func (f *EntryFailure) Unwrap() error {
+	return f.Err
+}
+
+// PutEntries writes entries as uncommitted entries on branch in a single transaction, for mass ingestion.
+// Entries that fail validation are skipped and returned as failures, the rest are written.  When a path appears
+// more than once the last entry is written.  Returns the number of entries written.
+func (c *cataloger) PutEntries(ctx context.Context, repository, branch string, entries []Entry) (int, []*EntryFailure, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}); err != nil {
+		return 0, nil, err
+	}
+
+	var failures []*EntryFailure
+	validEntries := make([]Entry, 0, len(entries))
+	for i := range entries {
+		if !IsValidPathLimits(entries[i].Path, c.Path) {
+			failures = append(failures, &EntryFailure{
+				Pos:  i,
+				Path: entries[i].Path,
+				Err:  fmt.Errorf("path: %w", ErrInvalidValue),
+			})
+			continue
+		}
+		validEntries = append(validEntries, entries[i])
+	}
+	entriesToInsert := lastEntriesByPath(validEntries)
+	if len(entriesToInsert) == 0 {
+		return 0, failures, nil
+	}
+
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.insertEntries(tx, branchID, entriesToInsert)
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return 0, failures, err
+	}
+	return len(entriesToInsert), failures, nil
+}
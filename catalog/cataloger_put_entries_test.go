@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_PutEntries(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repo := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create entry on master for testing",
+		c.CreateEntry(ctx, repo, "master",
+			Entry{Path: "/aaa/bbb/ccc1", Checksum: "cc", PhysicalAddress: "xx", Size: 1},
+			CreateEntryParams{}))
+
+	entries := []Entry{
+		{Path: "/aaa/bbb/ccc1", Checksum: "1231", PhysicalAddress: "5671", Size: 100},
+		{Path: "", Checksum: "1230", PhysicalAddress: "5670", Size: 10},
+		{Path: "/aaa/bbb/ccc2", Checksum: "1232", PhysicalAddress: "5672", Size: 200},
+		{Path: "/aaa/bbb/ccc2", Checksum: "1233", PhysicalAddress: "5673", Size: 300},
+	}
+	inserted, failures, err := c.PutEntries(ctx, repo, "master", entries)
+	testutil.MustDo(t, "put entries", err)
+	if inserted != 2 {
+		t.Errorf("PutEntries() inserted = %d, expected 2", inserted)
+	}
+	if len(failures) != 1 || failures[0].Pos != 1 || !errors.Is(failures[0], ErrInvalidValue) {
+		t.Errorf("PutEntries() failures = %v, expected invalid path at pos 1", failures)
+	}
+	expected := map[string]string{
+		"/aaa/bbb/ccc1": "5671",
+		"/aaa/bbb/ccc2": "5673",
+	}
+	for p, addr := range expected {
+		ent, err := c.GetEntry(ctx, repo, "master", p, GetEntryParams{})
+		testutil.MustDo(t, "get entry "+p, err)
+		if ent.PhysicalAddress != addr {
+			t.Errorf("Entry %s: address '%s', expected '%s'", p, ent.PhysicalAddress, addr)
+		}
+	}
+
+	if _, _, err := c.PutEntries(ctx, repo, "masterX", entries); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("PutEntries() to unknown branch err = %v, expected %s", err, ErrBranchNotFound)
+	}
+}
+
+func BenchmarkCataloger_PutEntries(b *testing.B) {
+	const batchSize = 1000
+	ctx := context.Background()
+	c := testCataloger(b)
+	repo := testCatalogerRepo(b, ctx, c, "repo", "master")
+	entries := make([]Entry, batchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range entries {
+			entries[j] = Entry{
+				Path:            randomFilepath("test_entry"),
+				Checksum:        strconv.Itoa(j),
+				PhysicalAddress: strconv.Itoa(j),
+				Size:            int64(j),
+			}
+		}
+		if _, _, err := c.PutEntries(ctx, repo, "master", entries); err != nil {
+			b.Fatal("put entries", err)
+		}
+	}
+}
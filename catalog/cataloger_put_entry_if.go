@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// EntryCondition is the condition PutEntryIf requires from the current entry before writing a new one
+type EntryCondition struct {
+	// IfMatch requires the current entry to exist with this checksum (S3 If-Match)
+	IfMatch string
+	// IfNoneMatch requires that there is no current entry (S3 If-None-Match: *)
+	IfNoneMatch bool
+}
+
+// PutEntryIf writes entry to path on branch only if the current committed or uncommitted entry satisfies
+// condition. Returns ErrPreconditionFailed when it does not.
+func (c *cataloger) PutEntryIf(ctx context.Context, repository, branch, path string, entry Entry, condition EntryCondition) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+		{Name: "path", IsValid: ValidatePathLimits(path, c.Path)},
+	}); err != nil {
+		return err
+	}
+	entry.Path = path
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// share lock - the checked entry should not be committed before we write
+		branchID, err := getBranchID(tx, repository, branch, LockTypeShare)
+		if err != nil {
+			return nil, fmt.Errorf("branch id: %w", err)
+		}
+		if err := lockEntryPath(tx, branchID, path); err != nil {
+			return nil, err
+		}
+
+		lineage, err := getLineage(tx, branchID, UncommittedID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		sql, args, err := psql.
+			Select("checksum").
+			FromSelect(sqEntriesLineage(branchID, UncommittedID, lineage), "entries").
+			Where(sq.Eq{"path": path, "is_deleted": false}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		var checksum string
+		err = tx.Get(&checksum, sql, args...)
+		exists := !errors.Is(err, db.ErrNotFound)
+		if err != nil && exists {
+			return nil, err
+		}
+		if condition.IfNoneMatch && exists {
+			return nil, ErrPreconditionFailed
+		}
+		if condition.IfMatch != "" && (!exists || checksum != condition.IfMatch) {
+			return nil, ErrPreconditionFailed
+		}
+		return insertEntry(tx, branchID, &entry)
+	}, c.txOpts(ctx)...)
+	return err
+}
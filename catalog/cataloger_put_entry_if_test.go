@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_PutEntryIf(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "committed", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "deleted", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit entries", "tester", nil)
+	testutil.MustDo(t, "commit entries", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "uncommitted", nil, "")
+	testutil.MustDo(t, "delete committed entry", c.DeleteEntry(ctx, repository, "master", "deleted"))
+
+	committedChecksum := testCreateEntryCalcChecksum("committed", "")
+	uncommittedChecksum := testCreateEntryCalcChecksum("uncommitted", "")
+	tests := []struct {
+		name      string
+		path      string
+		condition EntryCondition
+		wantErr   error
+	}{
+		{name: "no condition", path: "new1"},
+		{name: "none match on missing", path: "new2", condition: EntryCondition{IfNoneMatch: true}},
+		{name: "none match on deleted", path: "deleted", condition: EntryCondition{IfNoneMatch: true}},
+		{name: "none match on committed", path: "committed", condition: EntryCondition{IfNoneMatch: true}, wantErr: ErrPreconditionFailed},
+		{name: "none match on uncommitted", path: "uncommitted", condition: EntryCondition{IfNoneMatch: true}, wantErr: ErrPreconditionFailed},
+		{name: "match on missing", path: "new3", condition: EntryCondition{IfMatch: committedChecksum}, wantErr: ErrPreconditionFailed},
+		{name: "match different checksum", path: "uncommitted", condition: EntryCondition{IfMatch: committedChecksum}, wantErr: ErrPreconditionFailed},
+		{name: "match uncommitted", path: "uncommitted", condition: EntryCondition{IfMatch: uncommittedChecksum}},
+		{name: "match committed", path: "committed", condition: EntryCondition{IfMatch: committedChecksum}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checksum := "checksum-" + tt.path
+			err := c.PutEntryIf(ctx, repository, "master", tt.path, Entry{Checksum: checksum, PhysicalAddress: "addr-" + tt.path, Size: 1}, tt.condition)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PutEntryIf() err = %v, expected %v", err, tt.wantErr)
+			}
+			ent, _ := c.GetEntry(ctx, repository, "master", tt.path, GetEntryParams{})
+			written := ent != nil && ent.Checksum == checksum
+			if written != (tt.wantErr == nil) {
+				t.Fatalf("PutEntryIf() entry %+v, expected written %t", ent, tt.wantErr == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// MultipartUploadMaxParts is the highest part number of a multipart upload, as in S3
+const MultipartUploadMaxParts = 10000
+
+func (c *cataloger) PutMultipartUploadPart(ctx context.Context, repository, uploadID string, part MultipartUploadPart) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "uploadID", IsValid: ValidateUploadID(uploadID)},
+		{Name: "partNumber", IsValid: ValidateMultipartPartNumber(part.PartNumber)},
+		{Name: "physicalAddress", IsValid: ValidatePhysicalAddress(part.PhysicalAddress)},
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		if _, err := c.getMultipartUpload(tx, repository, uploadID, LockTypeShare); err != nil {
+			return nil, err
+		}
+		_, err := tx.Exec(`INSERT INTO catalog_multipart_upload_parts (upload_id,part_number,etag,size,physical_address)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (upload_id,part_number)
+			DO UPDATE SET etag=$3, size=$4, physical_address=$5, creation_date=now()`,
+			uploadID, part.PartNumber, part.ETag, part.Size, part.PhysicalAddress)
+		return nil, err
+	}, c.txOpts(ctx)...)
+	return err
+}
+
+// getMultipartUpload returns the upload uploadID of repository, ErrMultipartUploadNotFound if there is none
+func (c *cataloger) getMultipartUpload(tx db.Tx, repository, uploadID string, lockType LockType) (*MultipartUpload, error) {
+	repoID, err := c.getRepositoryIDCache(tx, repository)
+	if err != nil {
+		return nil, err
+	}
+	q, err := formatSQLWithLockType(`SELECT upload_id, path, creation_date, physical_address, metadata, content_type
+			FROM catalog_multipart_uploads
+			WHERE repository_id = $1 AND upload_id = $2`, lockType)
+	if err != nil {
+		return nil, err
+	}
+	var m MultipartUpload
+	err = tx.Get(&m, q, repoID, uploadID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, ErrMultipartUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Repository = repository
+	return &m, nil
+}
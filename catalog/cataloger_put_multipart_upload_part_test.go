@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_PutMultipartUploadPart(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create multipart upload",
+		c.CreateMultipartUpload(ctx, repository, "upload1", "/path1", "/file1", time.Now(), CreateMultipartUploadParams{}))
+
+	tests := []struct {
+		name     string
+		uploadID string
+		part     MultipartUploadPart
+		wantErr  error
+	}{
+		{name: "part", uploadID: "upload1", part: MultipartUploadPart{PartNumber: 2, ETag: "bb", Size: 20, PhysicalAddress: "/file1"}},
+		{name: "first part", uploadID: "upload1", part: MultipartUploadPart{PartNumber: 1, ETag: "aa", Size: 10, PhysicalAddress: "/file1"}},
+		{name: "replace part", uploadID: "upload1", part: MultipartUploadPart{PartNumber: 2, ETag: "cc", Size: 30, PhysicalAddress: "/file1"}},
+		{name: "part number zero", uploadID: "upload1", part: MultipartUploadPart{PartNumber: 0, ETag: "aa", PhysicalAddress: "/file1"}, wantErr: ErrInvalidValue},
+		{name: "part number too big", uploadID: "upload1", part: MultipartUploadPart{PartNumber: MultipartUploadMaxParts + 1, ETag: "aa", PhysicalAddress: "/file1"}, wantErr: ErrInvalidValue},
+		{name: "unknown upload", uploadID: "upload2", part: MultipartUploadPart{PartNumber: 1, ETag: "aa", PhysicalAddress: "/file1"}, wantErr: ErrMultipartUploadNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.PutMultipartUploadPart(ctx, repository, tt.uploadID, tt.part)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PutMultipartUploadPart() err = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	parts, err := c.ListMultipartUploadParts(ctx, repository, "upload1")
+	testutil.MustDo(t, "list parts", err)
+	expected := []MultipartUploadPart{
+		{PartNumber: 1, ETag: "aa", Size: 10, PhysicalAddress: "/file1"},
+		{PartNumber: 2, ETag: "cc", Size: 30, PhysicalAddress: "/file1"},
+	}
+	if len(parts) != len(expected) {
+		t.Fatalf("ListMultipartUploadParts() got %d parts, expected %d", len(parts), len(expected))
+	}
+	for i, part := range parts {
+		part.CreationDate = time.Time{}
+		if *part != expected[i] {
+			t.Errorf("ListMultipartUploadParts() part %d = %+v, expected %+v", i, *part, expected[i])
+		}
+	}
+	if _, err := c.ListMultipartUploadParts(ctx, repository, "upload2"); !errors.Is(err, ErrMultipartUploadNotFound) {
+		t.Fatalf("ListMultipartUploadParts() of unknown upload err = %v, expected %s", err, ErrMultipartUploadNotFound)
+	}
+}
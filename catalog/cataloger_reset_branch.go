@@ -2,28 +2,34 @@ package catalog
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) ResetBranch(ctx context.Context, repository, branch string) error {
+// ResetBranch discards all uncommitted changes on branch, returning it to the state of its last commit.
+// Returns the number of uncommitted entries removed.
+func (c *cataloger) ResetBranch(ctx context.Context, repository, branch string) (int64, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
-		return err
+		return 0, err
 	}
-	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, branch)
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// exclusive lock - do not race with commit or merge into the branch
+		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("branch id: %w", err)
 		}
 		res, err := tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1 AND min_commit=0`, branchID)
 		if err != nil {
 			return nil, err
 		}
-		_, err = res.RowsAffected()
-		return nil, err
+		return res.RowsAffected()
 	}, c.txOpts(ctx)...)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return res.(int64), nil
 }
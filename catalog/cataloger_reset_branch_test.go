@@ -11,10 +11,13 @@ func TestCataloger_ResetBranch_NoChanges(t *testing.T) {
 	ctx := context.Background()
 	c := testCataloger(t)
 	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
-	err := c.ResetBranch(ctx, repository, "master")
+	count, err := c.ResetBranch(ctx, repository, "master")
 	if err != nil {
 		t.Fatal("Reset branch should work on empty branch")
 	}
+	if count != 0 {
+		t.Fatalf("Reset branch with no changes count = %d, expected 0", count)
+	}
 }
 
 func TestCataloger_ResetBranch_ChangesOnBranch(t *testing.T) {
@@ -53,9 +56,15 @@ func TestCataloger_ResetBranch_ChangesOnBranch(t *testing.T) {
 		}
 	}
 
-	if err := c.ResetBranch(ctx, repository, "master"); err != nil {
+	count, err := c.ResetBranch(ctx, repository, "master")
+	if err != nil {
 		t.Fatal("Reset branch should work on empty branch")
 	}
+	// one delete and three new files
+	const expectedCount = 4
+	if count != expectedCount {
+		t.Fatalf("Reset branch count = %d, expected %d", count, expectedCount)
+	}
 	reference := MakeReference("master", UncommittedID)
 	entries, _, err := c.ListEntries(ctx, repository, reference, "", "", "", -1)
 	if err != nil {
@@ -109,9 +118,15 @@ func TestCataloger_ResetBranch_ChangesOnParent(t *testing.T) {
 		}
 	}
 
-	if err := c.ResetBranch(ctx, repository, "b1"); err != nil {
+	count, err := c.ResetBranch(ctx, repository, "b1")
+	if err != nil {
 		t.Fatal("Reset branch should work on empty branch")
 	}
+	// one delete and three new files
+	const expectedCount = 4
+	if count != expectedCount {
+		t.Fatalf("Reset branch count = %d, expected %d", count, expectedCount)
+	}
 	entries, _, err := c.ListEntries(ctx, repository, MakeReference("b1", UncommittedID), "", "", "", -1)
 	if err != nil {
 		t.Fatal("ListEntries for ResetBranch test:", err)
@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+const (
+	resetBranchCommitMessageFormat = "Branch '%s' reset to '%s'"
+)
+
+// ResetBranchToRef rewinds branch to targetRef, resolved like ResolveRef, and discards all uncommitted changes on
+// branch.  When the target is a commit of branch, the commits that follow it are dropped with their entries and
+// the target becomes the branch's last commit.  A target on another branch replaces all commits of branch with a
+// single commit whose parent is the target, as if branch was created from it.  Unless force is set, the target
+// must be an ancestor of the branch's last commit, otherwise ErrRefNotAncestor is returned.  Dropped commits must
+// not be referenced by tags or by other branches, otherwise ErrCommitReferenced is returned.  Returns the
+// reference of the branch's last commit after the reset.
+func (c *cataloger) ResetBranchToRef(ctx context.Context, repository, branch, targetRef string, force bool) (string, error) {
+	targetName, targetGenerations, err := parseRefAncestry(targetRef)
+	if err != nil {
+		return "", err
+	}
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+		{Name: "targetRef", IsValid: ValidateReference(targetName)},
+	}); err != nil {
+		return "", err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// exclusive lock - do not race with commit or merge into the branch
+		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("branch id: %w", err)
+		}
+		lastCommitID, err := getLastCommitIDByBranchID(tx, branchID)
+		if err != nil {
+			return nil, fmt.Errorf("last commit id: %w", err)
+		}
+		target, err := c.resolveRefAncestryCommit(tx, repository, targetName, targetGenerations)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", targetRef, ErrRefNotFound)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("target reference: %w", err)
+		}
+		if !force {
+			base, err := getMergeBase(tx, lineageCommit{BranchID: branchID, CommitID: lastCommitID}, target)
+			if err != nil && !errors.Is(err, ErrCommitNotFound) {
+				return nil, err
+			}
+			if err != nil || base != target {
+				return nil, ErrRefNotAncestor
+			}
+		}
+
+		if target.BranchID == branchID {
+			if err := rewindBranch(tx, branchID, target.CommitID); err != nil {
+				return nil, err
+			}
+			return MakeReference(branch, target.CommitID), nil
+		}
+		commitID, err := restartBranch(tx, branchID, target, fmt.Sprintf(resetBranchCommitMessageFormat, branch, targetRef))
+		if err != nil {
+			return nil, err
+		}
+		return MakeReference(branch, commitID), nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return "", err
+	}
+	return res.(string), nil
+}
+
+// rewindBranch drops the commits of branchID after commitID, with the entries they and the uncommitted changes
+// added, and makes the entries visible in commitID current again
+func rewindBranch(tx db.Tx, branchID int64, commitID CommitID) error {
+	if err := checkBranchCommitsUnreferenced(tx, branchID, commitID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1 AND (min_commit=0 OR min_commit>$2)`,
+		branchID, commitID); err != nil {
+		return fmt.Errorf("drop entries: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE catalog_entries SET max_commit=catalog_max_commit_id()
+			WHERE branch_id=$1 AND min_commit<=$2 AND max_commit>=$2 AND max_commit<catalog_max_commit_id()`,
+		branchID, commitID); err != nil {
+		return fmt.Errorf("restore entries: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM catalog_commits WHERE branch_id=$1 AND commit_id>$2`,
+		branchID, commitID); err != nil {
+		return fmt.Errorf("drop commits: %w", err)
+	}
+	return nil
+}
+
+// restartBranch drops all commits and entries of branchID and starts it over from source, on a new commit with
+// message.  Returns the new commit.
+func restartBranch(tx db.Tx, branchID int64, source lineageCommit, message string) (CommitID, error) {
+	if err := checkBranchCommitsUnreferenced(tx, branchID, 0); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1`, branchID); err != nil {
+		return 0, fmt.Errorf("drop entries: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM catalog_commits WHERE branch_id=$1`, branchID); err != nil {
+		return 0, fmt.Errorf("drop commits: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE catalog_branches SET lineage=(SELECT $2::bigint||lineage FROM catalog_branches WHERE id=$2)
+			WHERE id=$1`, branchID, source.BranchID); err != nil {
+		return 0, fmt.Errorf("update lineage: %w", err)
+	}
+	commitID, _, err := insertBranchCreationCommit(tx, branchID, source, message)
+	return commitID, err
+}
+
+// checkBranchCommitsUnreferenced returns ErrCommitReferenced if a tag or another branch references a commit of
+// branchID after commitID.  Branches reference the commits they were created from or merged with, directly or
+// through their lineage.
+func checkBranchCommitsUnreferenced(tx db.Tx, branchID int64, commitID CommitID) error {
+	var referenced bool
+	err := tx.Get(&referenced, `SELECT EXISTS (SELECT 1 FROM catalog_commits
+				WHERE branch_id<>$1 AND merge_source_branch=$1 AND merge_source_commit>$2)
+			OR EXISTS (SELECT 1 FROM catalog_tags WHERE branch_id=$1 AND commit_id>$2)`,
+		branchID, commitID)
+	if err != nil {
+		return fmt.Errorf("commit references: %w", err)
+	}
+	if referenced {
+		return ErrCommitReferenced
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func testCatalogerListPaths(t *testing.T, ctx context.Context, c Cataloger, repository, reference string) []string {
+	t.Helper()
+	entries, _, err := c.ListEntries(ctx, repository, reference, "", "", "", -1)
+	testutil.MustDo(t, "list entries", err)
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+func TestCataloger_ResetBranchToRef_Ancestor(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	firstCommit, err := c.Commit(ctx, repository, "master", "first", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "changed")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file3", nil, "")
+	lastCommit, err := c.Commit(ctx, repository, "master", "second", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file4", nil, "")
+
+	reference, err := c.ResetBranchToRef(ctx, repository, "master", "master~1", false)
+	testutil.MustDo(t, "reset branch to master~1", err)
+	if reference != firstCommit.Reference {
+		t.Fatalf("ResetBranchToRef() reference %s, expected %s", reference, firstCommit.Reference)
+	}
+	branchReference, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get branch reference", err)
+	if branchReference != firstCommit.Reference {
+		t.Fatalf("ResetBranchToRef() branch reference %s, expected %s", branchReference, firstCommit.Reference)
+	}
+	if _, err := c.GetCommit(ctx, repository, lastCommit.Reference); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetCommit() of dropped commit err = %v, expected %s", err, db.ErrNotFound)
+	}
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "master"), []string{"file1", "file2"}); diff != nil {
+		t.Fatal("ResetBranchToRef() entries", diff)
+	}
+	entry, err := c.GetEntry(ctx, repository, "master", "file1", GetEntryParams{})
+	testutil.MustDo(t, "get file1", err)
+	if expected := testCreateEntryCalcChecksum("file1", ""); entry.Checksum != expected {
+		t.Fatalf("ResetBranchToRef() file1 checksum %s, expected %s", entry.Checksum, expected)
+	}
+	hasChanges, err := c.HasUncommittedChanges(ctx, repository, "master")
+	testutil.MustDo(t, "has uncommitted changes", err)
+	if hasChanges {
+		t.Fatal("ResetBranchToRef() left uncommitted changes")
+	}
+
+	// commits after the reset continue from the target
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file5", nil, "")
+	nextCommit, err := c.Commit(ctx, repository, "master", "after reset", "tester", nil)
+	testutil.MustDo(t, "commit after reset", err)
+	if diff := deep.Equal(nextCommit.Parents, []string{firstCommit.Reference}); diff != nil {
+		t.Fatal("Commit() after reset parents", diff)
+	}
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "master"), []string{"file1", "file2", "file5"}); diff != nil {
+		t.Fatal("Commit() after reset entries", diff)
+	}
+}
+
+func TestCataloger_ResetBranchToRef_NotAncestor(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "feature1", "master")
+	testCatalogerBranch(t, ctx, c, repository, "feature2", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature1", "file2", nil, "")
+	_, err = c.Commit(ctx, repository, "feature1", "on feature1", "tester", nil)
+	testutil.MustDo(t, "commit on feature1", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature2", "file3", nil, "")
+	_, err = c.Commit(ctx, repository, "feature2", "on feature2", "tester", nil)
+	testutil.MustDo(t, "commit on feature2", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature2", "file4", nil, "")
+
+	_, err = c.ResetBranchToRef(ctx, repository, "feature2", "feature1", false)
+	if !errors.Is(err, ErrRefNotAncestor) {
+		t.Fatalf("ResetBranchToRef() to feature1 err = %v, expected %s", err, ErrRefNotAncestor)
+	}
+	// a refused reset keeps the uncommitted changes
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "feature2"), []string{"file1", "file3", "file4"}); diff != nil {
+		t.Fatal("ResetBranchToRef() refused entries", diff)
+	}
+
+	targetReference, err := c.ResolveRef(ctx, repository, "feature1")
+	testutil.MustDo(t, "resolve feature1", err)
+	reference, err := c.ResetBranchToRef(ctx, repository, "feature2", "feature1", true)
+	testutil.MustDo(t, "force reset branch to feature1", err)
+	commitLog, err := c.GetCommit(ctx, repository, reference)
+	testutil.MustDo(t, "get reset commit", err)
+	if diff := deep.Equal(commitLog.Parents, []string{targetReference}); diff != nil {
+		t.Fatal("ResetBranchToRef() forced commit parents", diff)
+	}
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "feature2"), []string{"file1", "file2"}); diff != nil {
+		t.Fatal("ResetBranchToRef() forced entries", diff)
+	}
+
+	// an ancestor on the source branch
+	_, err = c.ResetBranchToRef(ctx, repository, "feature2", "master", false)
+	testutil.MustDo(t, "reset branch to master", err)
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "feature2"), []string{"file1"}); diff != nil {
+		t.Fatal("ResetBranchToRef() to master entries", diff)
+	}
+}
+
+func TestCataloger_ResetBranchToRef_Referenced(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	secondCommit, err := c.Commit(ctx, repository, "master", "second", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1.0", secondCommit.Reference))
+	_, err = c.ResetBranchToRef(ctx, repository, "master", "master~1", false)
+	if !errors.Is(err, ErrCommitReferenced) {
+		t.Fatalf("ResetBranchToRef() over tagged commit err = %v, expected %s", err, ErrCommitReferenced)
+	}
+	testutil.MustDo(t, "delete tag", c.DeleteTag(ctx, repository, "v1.0"))
+
+	testCatalogerBranch(t, ctx, c, repository, "feature", "master")
+	_, err = c.ResetBranchToRef(ctx, repository, "master", "master~1", false)
+	if !errors.Is(err, ErrCommitReferenced) {
+		t.Fatalf("ResetBranchToRef() over branched commit err = %v, expected %s", err, ErrCommitReferenced)
+	}
+	if diff := deep.Equal(testCatalogerListPaths(t, ctx, c, repository, "master"), []string{"file1", "file2"}); diff != nil {
+		t.Fatal("ResetBranchToRef() refused entries", diff)
+	}
+}
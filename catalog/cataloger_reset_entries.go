@@ -2,25 +2,73 @@ package catalog
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) ResetEntries(ctx context.Context, repository, branch string, prefix string) error {
+// resetEntriesCondition selects the uncommitted entries (by branch_id and path LIKE) that reset removes
+const resetEntriesCondition = `branch_id=$1 AND path LIKE $2 AND min_commit=0`
+
+// ResetEntries discards the uncommitted changes, including deletes, of all entries under prefix.
+// Returns the number of uncommitted entries removed. An empty prefix resets the whole branch.
+func (c *cataloger) ResetEntries(ctx context.Context, repository, branch string, prefix string) (int64, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
-		return err
+		return 0, err
 	}
-	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, branch)
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// share lock - do not reset while the branch is committed
+		branchID, err := getBranchID(tx, repository, branch, LockTypeShare)
+		if err != nil {
+			return nil, fmt.Errorf("branch id: %w", err)
+		}
+		prefixCond := db.Prefix(prefix)
+		res, err := tx.Exec(`DELETE FROM catalog_entries WHERE `+resetEntriesCondition, branchID, prefixCond)
 		if err != nil {
 			return nil, err
 		}
+		return res.RowsAffected()
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.(int64), nil
+}
+
+// ResetEntriesPreview lists the paths ResetEntries would reset under prefix, without resetting them.
+// Paths are listed in order, limit and after page through the results.
+func (c *cataloger) ResetEntriesPreview(ctx context.Context, repository, branch string, prefix string, limit int, after string) ([]string, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}); err != nil {
+		return nil, false, err
+	}
+	if limit < 0 || limit > ListEntriesMaxLimit {
+		limit = ListEntriesMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// same lock as ResetEntries, so the preview matches what a reset would see
+		branchID, err := getBranchID(tx, repository, branch, LockTypeShare)
+		if err != nil {
+			return nil, fmt.Errorf("branch id: %w", err)
+		}
 		prefixCond := db.Prefix(prefix)
-		_, err = tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1 AND path LIKE $2 AND min_commit=0`, branchID, prefixCond)
-		return nil, err
+		var paths []string
+		err = tx.Select(&paths, `SELECT DISTINCT path FROM catalog_entries WHERE `+resetEntriesCondition+` AND path > $3 ORDER BY path LIMIT $4`,
+			branchID, prefixCond, after, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		return paths, nil
 	}, c.txOpts(ctx)...)
-	return err
+	if err != nil {
+		return nil, false, err
+	}
+	paths := res.([]string)
+	hasMore := paginateSlice(&paths, limit)
+	return paths, hasMore, nil
 }
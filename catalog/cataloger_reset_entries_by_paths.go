@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/treeverse/lakefs/db"
+)
+
+// ResetEntriesByPaths discards the uncommitted changes of all paths in a single transaction.
+// Returns for each path whether it had uncommitted changes to reset.
+func (c *cataloger) ResetEntriesByPaths(ctx context.Context, repository, branch string, paths []string) (map[string]bool, error) {
+	validators := ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
+	}
+	for _, p := range paths {
+		validators = append(validators, ValidateField{Name: "path", IsValid: ValidatePathLimits(p, c.Path)})
+	}
+	if err := Validate(validators); err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return map[string]bool{}, nil
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// share lock - do not reset while the branch is committed
+		branchID, err := getBranchID(tx, repository, branch, LockTypeShare)
+		if err != nil {
+			return nil, fmt.Errorf("branch id: %w", err)
+		}
+		var resetPaths []string
+		if err := tx.Select(&resetPaths, `DELETE FROM catalog_entries
+			WHERE branch_id=$1 AND path=ANY($2) AND min_commit=0
+			RETURNING path`, branchID, pq.Array(paths)); err != nil {
+			return nil, err
+		}
+		return resetPaths, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		results[p] = false
+	}
+	for _, p := range res.([]string) {
+		results[p] = true
+	}
+	return results, nil
+}
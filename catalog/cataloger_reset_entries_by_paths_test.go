@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ResetEntriesByPaths(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	for _, p := range []string{"/file1", "/file2", "/file3"} {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit files", "tester", nil)
+	testutil.MustDo(t, "commit files", err)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, repository, "master", "/file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file4", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file5", nil, "")
+
+	results, err := c.ResetEntriesByPaths(ctx, repository, "master", []string{"/file1", "/file2", "/file3", "/file4", "/fileX"})
+	testutil.MustDo(t, "reset entries by paths", err)
+	expectedResults := map[string]bool{
+		"/file1": true,
+		"/file2": true,
+		"/file3": false,
+		"/file4": true,
+		"/fileX": false,
+	}
+	if diff := deep.Equal(results, expectedResults); diff != nil {
+		t.Fatal("ResetEntriesByPaths", diff)
+	}
+
+	// only the path that was not requested is left uncommitted
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+	expectedDifferences := Differences{
+		Difference{Type: DifferenceTypeAdded, Path: "/file5"},
+	}
+	if diff := deep.Equal(testDifferencesTypeAndPath(differences), expectedDifferences); diff != nil {
+		t.Fatal("ResetEntriesByPaths left unexpected changes", diff)
+	}
+}
+
+func TestCataloger_ResetEntriesByPaths_Invalid(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+
+	// an invalid path fails the whole request
+	_, err := c.ResetEntriesByPaths(ctx, repository, "master", []string{"/file1", ""})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("ResetEntriesByPaths() err = %v, expected %s", err, ErrInvalidValue)
+	}
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "/file1", true)
+
+	_, err = c.ResetEntriesByPaths(ctx, repository, "nobranch", []string{"/file1"})
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("ResetEntriesByPaths() err = %v, expected not found", err)
+	}
+}
+
+func TestCataloger_ResetEntriesByPaths_ManyPaths(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+
+	// more paths than query parameters postgres accepts
+	const pathsCount = 70000
+	paths := make([]string, pathsCount)
+	for i := range paths {
+		paths[i] = "/file" + strconv.Itoa(i+1)
+	}
+	results, err := c.ResetEntriesByPaths(ctx, repository, "master", paths)
+	testutil.MustDo(t, "reset entries by many paths", err)
+	if len(results) != pathsCount || !results["/file1"] || results["/file2"] {
+		t.Fatalf("ResetEntriesByPaths() returned %d results, /file1=%t /file2=%t, expected %d results with only /file1 reset",
+			len(results), results["/file1"], results["/file2"], pathsCount)
+	}
+}
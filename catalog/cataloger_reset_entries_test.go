@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -95,7 +96,7 @@ func TestCataloger_ResetEntries_Basics(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := c.ResetEntries(ctx, tt.args.repository, tt.args.branch, tt.args.prefix); (err != nil) != tt.wantErr {
+			if _, err := c.ResetEntries(ctx, tt.args.repository, tt.args.branch, tt.args.prefix); (err != nil) != tt.wantErr {
 				t.Errorf("ResetEntries() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -182,7 +183,7 @@ func TestCataloger_ResetEntries(t *testing.T) {
 	testutil.Must(t, c.DeleteEntry(ctx, repository, "b1", "/file4"))
 
 	t.Run("reset master", func(t *testing.T) {
-		err := c.ResetEntries(ctx, repository, "master", "/file")
+		_, err := c.ResetEntries(ctx, repository, "master", "/file")
 		if err != nil {
 			t.Fatal("ResetEntries expected to succeed:", err)
 		}
@@ -198,7 +199,7 @@ func TestCataloger_ResetEntries(t *testing.T) {
 		}
 	})
 	t.Run("reset b1", func(t *testing.T) {
-		err := c.ResetEntries(ctx, repository, "b1", "/file")
+		_, err := c.ResetEntries(ctx, repository, "b1", "/file")
 		if err != nil {
 			t.Fatal("ResetEntries expected to succeed:", err)
 		}
@@ -215,3 +216,115 @@ func TestCataloger_ResetEntries(t *testing.T) {
 		}
 	})
 }
+
+func TestCataloger_ResetEntries_Prefix(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	committed := []string{"/a/file1", "/a/b/file1", "/a/b/c/file1", "/ab/file1"}
+	for _, p := range committed {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit files", "tester", nil)
+	testutil.MustDo(t, "commit files", err)
+
+	// change, delete (tombstone) and add entries in every level
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/a/file1", nil, "seed1")
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, repository, "master", "/a/b/file1"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/a/b/c/file2", nil, "")
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, repository, "master", "/ab/file1"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/ab/file2", nil, "")
+
+	// reset nested prefix
+	count, err := c.ResetEntries(ctx, repository, "master", "/a/b/")
+	testutil.MustDo(t, "reset /a/b/", err)
+	const expectedNestedCount = 2
+	if count != expectedNestedCount {
+		t.Fatalf("ResetEntries() count = %d, expected %d", count, expectedNestedCount)
+	}
+	differences, _, err := c.DiffUncommitted(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "diff uncommitted", err)
+	expectedDifferences := Differences{
+		Difference{Type: DifferenceTypeChanged, Path: "/a/file1"},
+		Difference{Type: DifferenceTypeRemoved, Path: "/ab/file1"},
+		Difference{Type: DifferenceTypeAdded, Path: "/ab/file2"},
+	}
+	if diff := deep.Equal(testDifferencesTypeAndPath(differences), expectedDifferences); diff != nil {
+		t.Fatal("ResetEntries left unexpected changes", diff)
+	}
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "/a/b/file1", true)
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "/a/b/c/file2", false)
+
+	// reset everything else
+	count, err = c.ResetEntries(ctx, repository, "master", "")
+	testutil.MustDo(t, "reset all", err)
+	const expectedAllCount = 3
+	if count != expectedAllCount {
+		t.Fatalf("ResetEntries() count = %d, expected %d", count, expectedAllCount)
+	}
+	for _, p := range committed {
+		testCatalogerGetEntry(t, ctx, c, repository, "master", p, true)
+	}
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "/ab/file2", false)
+
+	// nothing left to reset
+	count, err = c.ResetEntries(ctx, repository, "master", "")
+	testutil.MustDo(t, "reset all again", err)
+	if count != 0 {
+		t.Fatalf("ResetEntries() count = %d, expected 0", count)
+	}
+}
+
+func TestCataloger_ResetEntriesPreview(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	for _, p := range []string{"/a/file1", "/a/file2", "/b/file1"} {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", p, nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit files", "tester", nil)
+	testutil.MustDo(t, "commit files", err)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/a/file1", nil, "seed1")
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, repository, "master", "/a/file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/a/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/b/file2", nil, "")
+
+	// page through the preview
+	const limit = 2
+	var paths []string
+	var after string
+	for {
+		res, hasMore, err := c.ResetEntriesPreview(ctx, repository, "master", "/a/", limit, after)
+		testutil.MustDo(t, "reset entries preview", err)
+		if len(res) > limit {
+			t.Fatalf("ResetEntriesPreview() result length=%d, expected no more than %d", len(res), limit)
+		}
+		paths = append(paths, res...)
+		if !hasMore {
+			break
+		}
+		after = res[len(res)-1]
+	}
+	expectedPaths := []string{"/a/file1", "/a/file2", "/a/file3"}
+	if diff := deep.Equal(paths, expectedPaths); diff != nil {
+		t.Fatal("ResetEntriesPreview", diff)
+	}
+
+	// preview does not reset, and matches the reset count
+	count, err := c.ResetEntries(ctx, repository, "master", "/a/")
+	testutil.MustDo(t, "reset entries", err)
+	if count != int64(len(expectedPaths)) {
+		t.Fatalf("ResetEntries() count = %d, expected %d", count, len(expectedPaths))
+	}
+	paths, hasMore, err := c.ResetEntriesPreview(ctx, repository, "master", "", -1, "")
+	testutil.MustDo(t, "reset entries preview after reset", err)
+	if hasMore {
+		t.Fatal("ResetEntriesPreview() hasMore is true, expected false")
+	}
+	if diff := deep.Equal(paths, []string{"/b/file2"}); diff != nil {
+		t.Fatal("ResetEntriesPreview after reset", diff)
+	}
+}
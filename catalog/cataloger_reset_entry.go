@@ -6,10 +6,23 @@ import (
 	"github.com/treeverse/lakefs/db"
 )
 
+// ResetEntry discards the uncommitted change of path. Uncommitted deletes are tombstones (min_commit=0, max_commit=0),
+// removing them along with uncommitted writes makes the committed entry visible again. A path has at most one
+// uncommitted row (unique branch_id, path, min_commit), writing a deleted path replaces its tombstone.
+// Resetting a path with no uncommitted changes is a no-op.
 func (c *cataloger) ResetEntry(ctx context.Context, repository, branch string, path string) error {
+	return c.resetEntry(ctx, repository, branch, path, false)
+}
+
+// ResetEntryStrict is like ResetEntry, but returns ErrEntryNotFound when path has no uncommitted changes
+func (c *cataloger) ResetEntryStrict(ctx context.Context, repository, branch string, path string) error {
+	return c.resetEntry(ctx, repository, branch, path, true)
+}
+
+func (c *cataloger) resetEntry(ctx context.Context, repository, branch string, path string, strict bool) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "branch", Check: ValidateBranchName(branch)},
 	}); err != nil {
 		return err
 	}
@@ -27,7 +40,7 @@ func (c *cataloger) ResetEntry(ctx context.Context, repository, branch string, p
 		}
 		if affected, err := res.RowsAffected(); err != nil {
 			return nil, err
-		} else if affected != 1 {
+		} else if strict && affected == 0 {
 			return nil, ErrEntryNotFound
 		}
 		return nil, nil
@@ -53,7 +53,7 @@ func TestCataloger_ResetEntry(t *testing.T) {
 				branch:     branch,
 				path:       "/file1",
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name: "uncommitted file",
@@ -71,7 +71,7 @@ func TestCataloger_ResetEntry(t *testing.T) {
 				branch:     branch,
 				path:       "/fileX",
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name: "missing repository",
@@ -191,10 +191,31 @@ func TestCataloger_ResetEntry_Committed(t *testing.T) {
 	if _, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil); err != nil {
 		t.Fatal("Commit for reset entry test:", err)
 	}
-	err := c.ResetEntry(ctx, repository, "master", "/file1")
-	expectedErr := db.ErrNotFound
-	if !errors.As(err, &expectedErr) {
-		t.Fatal("ResetEntry expected not to find file in case nothing to reset: ", err)
+	if err := c.ResetEntry(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("ResetEntry expected to succeed in case nothing to reset:", err)
+	}
+	testCatalogerGetEntry(t, ctx, c, repository, "master", "/file1", true)
+	err := c.ResetEntryStrict(ctx, repository, "master", "/file1")
+	if !errors.Is(err, ErrEntryNotFound) {
+		t.Fatal("ResetEntryStrict expected not to find file in case nothing to reset:", err)
+	}
+}
+
+func TestCataloger_ResetEntryStrict(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	if err := c.ResetEntryStrict(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("ResetEntryStrict should reset new uncommitted file:", err)
+	}
+	// second reset has nothing to do
+	if err := c.ResetEntryStrict(ctx, repository, "master", "/file1"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("ResetEntryStrict err = %v, expected %s", err, ErrEntryNotFound)
+	}
+	if err := c.ResetEntry(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("ResetEntry expected to succeed in case nothing to reset:", err)
 	}
 }
 
@@ -296,3 +317,71 @@ func TestCataloger_ResetEntry_UncommittedDeleteParentBranch(t *testing.T) {
 		t.Fatalf("Entry should be reseted back to /file1 /addr1, got %+v", ent)
 	}
 }
+
+func TestCataloger_ResetEntry_DeletedToCommitted(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	if _, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil); err != nil {
+		t.Fatal("Commit for reset entry test:", err)
+	}
+	testCatalogerBranch(t, ctx, c, repository, "b1", "master")
+
+	// delete the committed file on master and on a child branch (which sees it through its lineage)
+	for _, branch := range []string{"master", "b1"} {
+		if err := c.DeleteEntry(ctx, repository, branch, "/file1"); err != nil {
+			t.Fatalf("delete entry on %s for reset entry test: %s", branch, err)
+		}
+		testCatalogerGetEntry(t, ctx, c, repository, branch, "/file1", false)
+		if err := c.ResetEntry(ctx, repository, branch, "/file1"); err != nil {
+			t.Fatalf("ResetEntry should reset uncommitted delete on %s: %s", branch, err)
+		}
+		ent, err := c.GetEntry(ctx, repository, branch, "/file1", GetEntryParams{})
+		if err != nil {
+			t.Fatalf("ResetEntry expecting committed file to be found on %s: %s", branch, err)
+		}
+		expectedChecksum := testCreateEntryCalcChecksum("/file1", "")
+		if ent.Checksum != expectedChecksum {
+			t.Errorf("ResetEntry should restore committed entry on %s with checksum %s, got %s", branch, expectedChecksum, ent.Checksum)
+		}
+	}
+}
+
+func TestCataloger_ResetEntry_DeletedAndRecreated(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	if _, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil); err != nil {
+		t.Fatal("Commit for reset entry test:", err)
+	}
+
+	// tombstone the committed entry and write it again
+	if err := c.DeleteEntry(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("delete entry for reset entry test:", err)
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	if err := c.DeleteEntry(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("delete entry for reset entry test:", err)
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed2")
+
+	if err := c.ResetEntryStrict(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("ResetEntryStrict should reset the recreated entry:", err)
+	}
+	ent, err := c.GetEntry(ctx, repository, "master", "/file1", GetEntryParams{})
+	if err != nil {
+		t.Fatal("ResetEntry expecting committed file to be found:", err)
+	}
+	expectedChecksum := testCreateEntryCalcChecksum("/file1", "")
+	if ent.Checksum != expectedChecksum {
+		t.Errorf("ResetEntry should restore committed entry with checksum %s, got %s", expectedChecksum, ent.Checksum)
+	}
+	// nothing left uncommitted
+	if err := c.ResetEntryStrict(ctx, repository, "master", "/file1"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("ResetEntryStrict err = %v, expected %s", err, ErrEntryNotFound)
+	}
+}
@@ -0,0 +1,153 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// RefAncestrySeparator separates a ref from the number of generations to walk back, as in "master~2"
+const RefAncestrySeparator = "~"
+
+// ResolveRef returns the commit reference ref points to.  A ref is resolved as a branch name, pointing to the
+// branch's last commit, then as a tag name and last as a commit reference.  A "~N" suffix walks back N first
+// parents from the resolved commit, "~" alone walks back one.  Returns ErrRefNotFound if ref does not resolve.
+func (c *cataloger) ResolveRef(ctx context.Context, repository, ref string) (string, error) {
+	name, generations, err := parseRefAncestry(ref)
+	if err != nil {
+		return "", err
+	}
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "ref", IsValid: ValidateReference(name)},
+	}); err != nil {
+		return "", err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		commit, err := c.resolveRefAncestryCommit(tx, repository, name, generations)
+		if err != nil {
+			return nil, err
+		}
+		return getCommitReference(tx, commit)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if errors.Is(err, db.ErrNotFound) {
+		return "", fmt.Errorf("%s: %w", ref, ErrRefNotFound)
+	}
+	if err != nil {
+		return "", err
+	}
+	return res.(string), nil
+}
+
+// resolveRefAncestryCommit returns the commit reached by walking back generations first parents from the
+// branch, tag or commit reference name
+func (c *cataloger) resolveRefAncestryCommit(tx db.Tx, repository, name string, generations int) (lineageCommit, error) {
+	branchID, commitID, err := c.resolveRefCommit(tx, repository, name)
+	if err != nil {
+		return lineageCommit{}, err
+	}
+	for i := 0; i < generations; i++ {
+		branchID, commitID, err = getCommitFirstParent(tx, branchID, commitID)
+		if err != nil {
+			return lineageCommit{}, err
+		}
+	}
+	return lineageCommit{BranchID: branchID, CommitID: commitID}, nil
+}
+
+// getCommitReference returns the commit reference of commit
+func getCommitReference(tx db.Tx, commit lineageCommit) (string, error) {
+	var branchName string
+	if err := tx.Get(&branchName, `SELECT name FROM catalog_branches WHERE id=$1`, commit.BranchID); err != nil {
+		return "", fmt.Errorf("branch name: %w", err)
+	}
+	return MakeReference(branchName, commit.CommitID), nil
+}
+
+// resolveRefAncestry returns ref resolved by ResolveRef if it has a "~N" ancestry suffix.  Any other ref is
+// returned as is, for reads that accept branch, tag and commit references directly.
+func (c *cataloger) resolveRefAncestry(ctx context.Context, repository, ref string) (string, error) {
+	name, _, err := parseRefAncestry(ref)
+	if err != nil {
+		return "", err
+	}
+	if name == ref {
+		return ref, nil
+	}
+	return c.ResolveRef(ctx, repository, ref)
+}
+
+// parseRefAncestry splits the "~N" ancestry suffix from ref.  Commit references start with CommitPrefix and
+// never include it again, so only a separator after the first character is a suffix.
+func parseRefAncestry(ref string) (string, int, error) {
+	idx := strings.LastIndex(ref, RefAncestrySeparator)
+	if idx <= 0 {
+		return ref, 0, nil
+	}
+	suffix := ref[idx+len(RefAncestrySeparator):]
+	if suffix == "" {
+		return ref[:idx], 1, nil
+	}
+	generations, err := strconv.Atoi(suffix)
+	if err != nil || generations < 0 {
+		return "", 0, fmt.Errorf("%w: ref ancestry", ErrInvalidValue)
+	}
+	return ref[:idx], generations, nil
+}
+
+// resolveRefCommit returns the branch and commit of a branch, tag or commit reference, in that order
+func (c *cataloger) resolveRefCommit(tx db.Tx, repository, name string) (int64, CommitID, error) {
+	ref, err := ParseRef(name)
+	if err != nil {
+		return 0, 0, err
+	}
+	ref, err = c.resolveTagRef(tx, repository, ref)
+	if err != nil {
+		return 0, 0, err
+	}
+	branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ref.CommitID == UncommittedID || ref.CommitID == CommittedID {
+		commitID, err := getLastCommitIDByBranchID(tx, branchID)
+		return branchID, commitID, err
+	}
+	var commitExists bool
+	err = tx.Get(&commitExists, `SELECT EXISTS (SELECT 1 FROM catalog_commits WHERE branch_id=$1 AND commit_id=$2)`,
+		branchID, ref.CommitID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !commitExists {
+		return 0, 0, ErrCommitNotFound
+	}
+	return branchID, ref.CommitID, nil
+}
+
+// getCommitFirstParent returns the first parent of a commit: the previous commit on its branch, or for the first
+// commit of a branch the commit on the source branch it was created from
+func getCommitFirstParent(tx db.Tx, branchID int64, commitID CommitID) (int64, CommitID, error) {
+	var commit struct {
+		PreviousCommitID  CommitID     `db:"previous_commit_id"`
+		MergeType         RelationType `db:"merge_type"`
+		MergeSourceBranch *int64       `db:"merge_source_branch"`
+		MergeSourceCommit *CommitID    `db:"merge_source_commit"`
+	}
+	err := tx.Get(&commit, `SELECT previous_commit_id, merge_type, merge_source_branch, merge_source_commit
+			FROM catalog_commits WHERE branch_id=$1 AND commit_id=$2`, branchID, commitID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if commit.PreviousCommitID > 0 {
+		return branchID, commit.PreviousCommitID, nil
+	}
+	if commit.MergeType == RelationTypeFromParent && commit.MergeSourceBranch != nil && commit.MergeSourceCommit != nil {
+		return *commit.MergeSourceBranch, *commit.MergeSourceCommit, nil
+	}
+	return 0, 0, ErrCommitNotFound
+}
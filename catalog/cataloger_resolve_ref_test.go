@@ -0,0 +1,63 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ResolveRef(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commit1, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	commit2, err := c.Commit(ctx, repository, "master", "commit file2", "tester", nil)
+	testutil.MustDo(t, "commit file2", err)
+	branchCommit, err := c.CreateBranch(ctx, repository, "branch1", "master")
+	testutil.MustDo(t, "create branch1", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file3", nil, "")
+	commit3, err := c.Commit(ctx, repository, "branch1", "commit file3", "tester", nil)
+	testutil.MustDo(t, "commit file3", err)
+	testutil.MustDo(t, "create tag", c.CreateTag(ctx, repository, "v1.0", commit1.Reference))
+	testutil.MustDo(t, "create tag named as branch", c.CreateTag(ctx, repository, "branch1", commit1.Reference))
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr error
+	}{
+		{name: "branch", ref: "master", want: commit2.Reference},
+		{name: "branch head", ref: "master:HEAD", want: commit2.Reference},
+		{name: "branch over tag", ref: "branch1", want: commit3.Reference},
+		{name: "tag", ref: "v1.0", want: commit1.Reference},
+		{name: "commit", ref: commit1.Reference, want: commit1.Reference},
+		{name: "ancestor", ref: "master~1", want: commit1.Reference},
+		{name: "parent", ref: "master~", want: commit1.Reference},
+		{name: "no ancestry", ref: "master~0", want: commit2.Reference},
+		{name: "ancestor of branch", ref: "branch1~1", want: branchCommit.Reference},
+		{name: "ancestor from source branch", ref: "branch1~2", want: commit2.Reference},
+		{name: "ancestor of tag", ref: "v1.0~0", want: commit1.Reference},
+		{name: "ancestor of commit", ref: commit2.Reference + "~1", want: commit1.Reference},
+		{name: "unknown", ref: "unknown", wantErr: ErrRefNotFound},
+		{name: "unknown commit", ref: MakeReference("master", 9999), wantErr: ErrRefNotFound},
+		{name: "too many ancestors", ref: "master~100", wantErr: ErrRefNotFound},
+		{name: "invalid ancestry", ref: "master~x", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.ResolveRef(ctx, repository, tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveRef() err = %v, expected %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ResolveRef() = %s, expected %s", got, tt.want)
+			}
+		})
+	}
+}
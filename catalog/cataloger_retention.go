@@ -65,7 +65,7 @@ func (a *Rules) Scan(value interface{}) error {
 const entriesTable = "catalog_entries"
 
 func byRepository(repository string) sq.Sqlizer {
-	return sq.Expr("catalog_repositories.name = ?", repository)
+	return sq.Expr("catalog_repositories.name = ? AND catalog_repositories.deleted_at IS NULL", repository)
 }
 
 func byPathPrefix(pathPrefix string) sq.Sqlizer {
@@ -305,7 +305,7 @@ func (c *cataloger) MarkObjectsForDeletion(ctx context.Context, repositoryName s
 	// dedup_id (this is not yet the real deletion).
 	result, err := c.db.WithContext(ctx).Exec(`
                     UPDATE catalog_object_dedup SET deleting=true
-                    WHERE repository_id IN (SELECT id FROM catalog_repositories WHERE name = $1) AND
+                    WHERE repository_id IN (SELECT id FROM catalog_repositories WHERE name = $1 AND deleted_at IS NULL) AND
                           physical_address IN (
                               SELECT physical_address FROM (
                                 SELECT physical_address, bool_and(is_expired) all_expired
@@ -345,7 +345,7 @@ func (s *StringRows) Read() (string, error) {
 //     the duration.
 func (c *cataloger) DeleteOrUnmarkObjectsForDeletion(ctx context.Context, repositoryName string) (StringRows, error) {
 	rows, err := c.db.WithContext(ctx).Query(`
-		WITH ids AS (SELECT id repository_id FROM catalog_repositories WHERE name = $1),
+		WITH ids AS (SELECT id repository_id FROM catalog_repositories WHERE name = $1 AND deleted_at IS NULL),
 		    update_result AS (
 			UPDATE catalog_object_dedup SET deleting=all_expired
 			 FROM (
@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// DefaultContentType is the content type of objects whose type is not known
+const DefaultContentType = "application/octet-stream"
+
+// EntryStat is the subset of an entry needed to check an object without reading it
+type EntryStat struct {
+	Size         int64     `db:"size"`
+	Checksum     string    `db:"checksum"`
+	CreationDate time.Time `db:"creation_date"`
+	ContentType  string    `db:"content_type"`
+	Expired      bool      `db:"is_expired"`
+}
+
+// StatEntry returns the size, checksum, modification time and content type of the object at path in ref, reading
+// only those fields.  ref can be any reference accepted by ResolveRef.  Returns ErrEntryNotFound if there is no
+// object at path, and the stat with ErrExpired if the object expired from storage.
+func (c *cataloger) StatEntry(ctx context.Context, repository, ref, path string) (EntryStat, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "path", IsValid: ValidatePathLimits(path, c.Path)},
+	}); err != nil {
+		return EntryStat{}, err
+	}
+	reference, err := c.resolveRefAncestry(ctx, repository, ref)
+	if err != nil {
+		return EntryStat{}, err
+	}
+	if err := Validate(ValidateFields{
+		{Name: "reference", IsValid: ValidateReference(reference)},
+	}); err != nil {
+		return EntryStat{}, err
+	}
+	parsedRef, err := ParseRef(reference)
+	if err != nil {
+		return EntryStat{}, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		parsedRef, err := c.resolveTagRef(tx, repository, parsedRef)
+		if err != nil {
+			return nil, err
+		}
+		branchID, err := c.getBranchIDCache(tx, repository, parsedRef.Branch)
+		if err != nil {
+			return nil, err
+		}
+		lineage, err := getLineage(tx, branchID, parsedRef.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		sql, args, err := psql.
+			Select("size", "checksum", "creation_date", "content_type", "is_expired").
+			FromSelect(sqEntriesLineage(branchID, parsedRef.CommitID, lineage), "entries").
+			Where(sq.Eq{"path": path, "is_deleted": false}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		var stat EntryStat
+		if err := tx.Get(&stat, sql, args...); err != nil {
+			return nil, err
+		}
+		return &stat, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if errors.Is(err, db.ErrNotFound) && !errors.Is(err, ErrBranchNotFound) {
+		return EntryStat{}, ErrEntryNotFound
+	}
+	if err != nil {
+		return EntryStat{}, err
+	}
+	stat := res.(*EntryStat)
+	if stat.ContentType == "" {
+		stat.ContentType = ContentTypeByPath(path)
+	}
+	if stat.Expired {
+		return *stat, ErrExpired
+	}
+	return *stat, nil
+}
+
+// ContentTypeByPath guesses the content type of an object from its path extension
+func ContentTypeByPath(p string) string {
+	if contentType := mime.TypeByExtension(path.Ext(p)); contentType != "" {
+		return contentType
+	}
+	return DefaultContentType
+}
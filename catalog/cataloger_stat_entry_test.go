@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_StatEntry(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create data.json", c.CreateEntry(ctx, repository, "master",
+		Entry{Path: "data.json", Checksum: "aa", PhysicalAddress: "aa", Size: 10, Metadata: Metadata{"k": "v"}},
+		CreateEntryParams{}))
+	_, err := c.Commit(ctx, repository, "master", "commit data.json", "tester", nil)
+	testutil.MustDo(t, "commit data.json", err)
+	testutil.MustDo(t, "create data.bin", c.CreateEntry(ctx, repository, "master",
+		Entry{Path: "data.bin.x", Checksum: "bb", PhysicalAddress: "bb", Size: 20},
+		CreateEntryParams{}))
+	testutil.MustDo(t, "create expired", c.CreateEntry(ctx, repository, "master",
+		Entry{Path: "expired.txt", Checksum: "cc", PhysicalAddress: "cc", Size: 30, Expired: true},
+		CreateEntryParams{}))
+
+	tests := []struct {
+		name            string
+		ref             string
+		path            string
+		wantSize        int64
+		wantChecksum    string
+		wantContentType string
+		wantErr         error
+	}{
+		{name: "committed", ref: "master", path: "data.json", wantSize: 10, wantChecksum: "aa", wantContentType: "application/json"},
+		{name: "uncommitted", ref: "master", path: "data.bin.x", wantSize: 20, wantChecksum: "bb", wantContentType: DefaultContentType},
+		{name: "ancestry", ref: "master~0", path: "data.json", wantSize: 10, wantChecksum: "aa", wantContentType: "application/json"},
+		{name: "expired", ref: "master", path: "expired.txt", wantSize: 30, wantChecksum: "cc", wantContentType: "text/plain; charset=utf-8", wantErr: ErrExpired},
+		{name: "uncommitted in commit", ref: "master~0", path: "data.bin.x", wantErr: ErrEntryNotFound},
+		{name: "missing", ref: "master", path: "missing", wantErr: ErrEntryNotFound},
+		{name: "unknown branch", ref: "unknown", path: "data.json", wantErr: ErrBranchNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stat, err := c.StatEntry(ctx, repository, tt.ref, tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StatEntry() err = %v, expected %v", err, tt.wantErr)
+			}
+			if stat.Size != tt.wantSize || stat.Checksum != tt.wantChecksum || stat.ContentType != tt.wantContentType {
+				t.Fatalf("StatEntry() = %+v, expected size %d, checksum %s, content type %s",
+					stat, tt.wantSize, tt.wantChecksum, tt.wantContentType)
+			}
+			if tt.wantErr == nil && stat.CreationDate.IsZero() {
+				t.Fatal("StatEntry() missing creation date")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Algorithms of the entry Checksum, in addition to those of Checksums
+const (
+	ChecksumAlgorithmMD5          ChecksumAlgorithm = "md5"
+	ChecksumAlgorithmMultipartMD5 ChecksumAlgorithm = "md5-multipart"
+)
+
+// checksumAlgorithmPatterns recognize the algorithm of an entry checksum by its format.  The patterns are matched
+// by the database as well, so they must be valid as both Go and PostgreSQL regular expressions.
+var checksumAlgorithmPatterns = []struct {
+	algorithm ChecksumAlgorithm
+	pattern   string
+	re        *regexp.Regexp
+}{
+	{algorithm: ChecksumAlgorithmMD5, pattern: `^[0-9a-f]{32}$`},
+	{algorithm: ChecksumAlgorithmMultipartMD5, pattern: `^[0-9a-f]{32}-[0-9]+$`},
+	{algorithm: ChecksumAlgorithmSHA256, pattern: `^[0-9a-f]{64}$`},
+}
+
+func init() {
+	for i := range checksumAlgorithmPatterns {
+		checksumAlgorithmPatterns[i].re = regexp.MustCompile(checksumAlgorithmPatterns[i].pattern)
+	}
+}
+
+// EntryChecksumAlgorithm returns the algorithm that computed the entry checksum, judging by its format, or an
+// empty string if it is not recognized
+func EntryChecksumAlgorithm(checksum string) ChecksumAlgorithm {
+	for _, p := range checksumAlgorithmPatterns {
+		if p.re.MatchString(checksum) {
+			return p.algorithm
+		}
+	}
+	return ""
+}
+
+// sqChecksumAlgorithm returns an SQL expression of the EntryChecksumAlgorithm of the checksum column
+func sqChecksumAlgorithm(column string) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, p := range checksumAlgorithmPatterns {
+		fmt.Fprintf(&b, " WHEN %s ~ '%s' THEN '%s'", column, p.pattern, p.algorithm)
+	}
+	b.WriteString(" ELSE '' END")
+	return b.String()
+}
+
+// sqSameContent returns an SQL condition that is true when the entries of the left and right tables hold the same
+// content.  Checksums of the same algorithm are compared.  Checksums of different algorithms, as found after the
+// checksum algorithm changed, cannot be compared and the entries are the same only if they point to the same object.
+func sqSameContent(left, right string) string {
+	return fmt.Sprintf("(%[1]s.checksum=%[2]s.checksum OR (%[3]s<>%[4]s AND %[1]s.physical_address=%[2]s.physical_address))",
+		left, right, sqChecksumAlgorithm(left+".checksum"), sqChecksumAlgorithm(right+".checksum"))
+}
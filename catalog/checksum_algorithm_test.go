@@ -0,0 +1,26 @@
+package catalog
+
+import "testing"
+
+func TestEntryChecksumAlgorithm(t *testing.T) {
+	tests := []struct {
+		name     string
+		checksum string
+		want     ChecksumAlgorithm
+	}{
+		{name: "md5", checksum: "5eb63bbbe01eeed093cb22bb8f5acdc3", want: ChecksumAlgorithmMD5},
+		{name: "multipart md5", checksum: "4ba54d90a25d93d94b7b41c987352341-2", want: ChecksumAlgorithmMultipartMD5},
+		{name: "sha256", checksum: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", want: ChecksumAlgorithmSHA256},
+		{name: "empty", checksum: "", want: ""},
+		{name: "quoted", checksum: `"5eb63bbbe01eeed093cb22bb8f5acdc3"`, want: ""},
+		{name: "upper case", checksum: "5EB63BBBE01EEED093CB22BB8F5ACDC3", want: ""},
+		{name: "missing parts", checksum: "4ba54d90a25d93d94b7b41c987352341-", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EntryChecksumAlgorithm(tt.checksum); got != tt.want {
+				t.Errorf("EntryChecksumAlgorithm() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
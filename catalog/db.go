@@ -23,7 +23,7 @@ var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 func getBranchID(tx db.Tx, repository, branch string, lockType LockType) (int64, error) {
 	const b = `SELECT b.id FROM catalog_branches b join catalog_repositories r 
 					ON r.id = b.repository_id
-					WHERE r.name = $1 AND b.name = $2`
+					WHERE r.name = $1 AND b.name = $2 AND r.deleted_at IS NULL`
 	q, err := formatSQLWithLockType(b, lockType)
 	if err != nil {
 		return 0, err
@@ -33,6 +33,16 @@ func getBranchID(tx db.Tx, repository, branch string, lockType LockType) (int64,
 	return branchID, err
 }
 
+// lockEntryPath takes a transaction level advisory lock on path of branchID, serializing concurrent writes to the
+// same object while writes to other paths proceed.  Paths sharing a lock key hash are serialized too.
+func lockEntryPath(tx db.Tx, branchID int64, path string) error {
+	_, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtextextended($2, $1))`, branchID, path)
+	if err != nil {
+		return fmt.Errorf("lock entry path: %w", err)
+	}
+	return nil
+}
+
 func formatSQLWithLockType(sql string, lockType LockType) (string, error) {
 	var q string
 	switch lockType {
@@ -50,7 +60,7 @@ func formatSQLWithLockType(sql string, lockType LockType) (string, error) {
 
 func getRepositoryID(tx db.Tx, repository string) (int, error) {
 	var repoID int
-	err := tx.Get(&repoID, `SELECT id FROM catalog_repositories WHERE name=$1`, repository)
+	err := tx.Get(&repoID, `SELECT id FROM catalog_repositories WHERE name=$1 AND deleted_at IS NULL`, repository)
 	return repoID, err
 }
 
@@ -70,7 +80,7 @@ func getRepository(tx db.Tx, repository string) (*Repository, error) {
 	var r Repository
 	err := tx.Get(&r, `SELECT r.name, r.storage_namespace, b.name as default_branch, r.creation_date
 			FROM catalog_repositories r, catalog_branches b
-			WHERE r.id = b.repository_id AND r.default_branch = b.id AND r.name = $1`,
+			WHERE r.id = b.repository_id AND r.default_branch = b.id AND r.name = $1 AND r.deleted_at IS NULL`,
 		repository)
 	if err != nil {
 		return nil, err
@@ -151,3 +161,20 @@ func getLineageAsValues(lineage []lineageCommit, branchID int64, commitID Commit
 	valTable := "(VALUES " + strings.Join(valArray, " ,\n ") + ") as l(precedence,branch_id,commit_id) "
 	return valTable
 }
+
+// getTagRef returns the reference of the commit tagged by name
+func getTagRef(tx db.Tx, repository, name string) (*Ref, error) {
+	var tag struct {
+		Branch   string   `db:"branch"`
+		CommitID CommitID `db:"commit_id"`
+	}
+	err := tx.Get(&tag, `SELECT b.name AS branch, t.commit_id FROM catalog_tags t
+			JOIN catalog_repositories r ON r.id = t.repository_id
+			JOIN catalog_branches b ON b.id = t.branch_id
+			WHERE r.name = $1 AND t.name = $2 AND r.deleted_at IS NULL`,
+		repository, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Ref{Branch: tag.Branch, CommitID: tag.CommitID}, nil
+}
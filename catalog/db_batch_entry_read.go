@@ -139,7 +139,7 @@ func (c *cataloger) dbSelectBatchEntries(repository string, ref Ref, pathReqList
 			p[i] = s.path
 		}
 		// prepare query
-		readExpr := sq.Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "is_expired").
+		readExpr := sq.Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "checksums", "content_type", "expires_at", "is_expired").
 			FromSelect(sqEntriesLineage(branchID, ref.CommitID, lineage), "entries").
 			Where(sq.And{sq.Eq{"path": p}, sq.Expr("not is_deleted")})
 		query, args, err := readExpr.PlaceholderFormat(sq.Dollar).ToSql()
@@ -1,5 +1,7 @@
 package catalog
 
+import "time"
+
 type DifferenceType int
 
 const (
@@ -12,6 +14,10 @@ const (
 type Difference struct {
 	Type DifferenceType `db:"diff_type"`
 	Path string         `db:"path"`
+	// Size and CreationDate describe the changed object, removed objects report the last committed one.
+	// Currently set only by DiffUncommitted.
+	Size         int64     `db:"size"`
+	CreationDate time.Time `db:"creation_date"`
 }
 
 func (d Difference) String() string {
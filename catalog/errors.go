@@ -8,22 +8,62 @@ import (
 )
 
 var (
-	ErrFeatureNotSupported      = errors.New("feature not supported")
-	ErrOperationNotPermitted    = errors.New("operation not permitted")
-	ErrInvalidLockValue         = errors.New("invalid lock value")
-	ErrNothingToCommit          = errors.New("nothing to commit")
-	ErrNoDifferenceWasFound     = errors.New("no difference was found")
-	ErrConflictFound            = errors.New("conflict found")
-	ErrUnsupportedRelation      = errors.New("unsupported relation")
-	ErrUnsupportedDelimiter     = errors.New("unsupported delimiter")
-	ErrInvalidReference         = errors.New("invalid reference")
-	ErrBranchNotFound           = fmt.Errorf("branch %w", db.ErrNotFound)
-	ErrCommitNotFound           = fmt.Errorf("commit %w", db.ErrNotFound)
-	ErrRepositoryNotFound       = fmt.Errorf("repository %w", db.ErrNotFound)
-	ErrMultipartUploadNotFound  = fmt.Errorf("multipart upload %w", db.ErrNotFound)
-	ErrEntryNotFound            = fmt.Errorf("entry %w", db.ErrNotFound)
-	ErrByteSliceTypeAssertion   = errors.New("type assertion to []byte failed")
-	ErrInvalidMetadataSrcFormat = errors.New("invalid metadata src format")
-	ErrUnexpected               = errors.New("unexpected error")
-	ErrReadEntryTimeout         = errors.New("read entry timeout")
+	ErrFeatureNotSupported         = errors.New("feature not supported")
+	ErrOperationNotPermitted       = errors.New("operation not permitted")
+	ErrInvalidLockValue            = errors.New("invalid lock value")
+	ErrNothingToCommit             = errors.New("nothing to commit")
+	ErrNoDifferenceWasFound        = errors.New("no difference was found")
+	ErrConflictFound               = errors.New("conflict found")
+	ErrBranchModified              = errors.New("branch modified")
+	ErrUnsupportedRelation         = errors.New("unsupported relation")
+	ErrUnsupportedDelimiter        = errors.New("unsupported delimiter")
+	ErrInvalidReference            = errors.New("invalid reference")
+	ErrBranchNotFound              = fmt.Errorf("branch %w", db.ErrNotFound)
+	ErrBranchAlreadyExists         = fmt.Errorf("branch %w", db.ErrAlreadyExists)
+	ErrBranchHasUncommittedChanges = errors.New("branch has uncommitted changes")
+	ErrCannotDeleteDefaultBranch   = errors.New("cannot delete default branch")
+	ErrBranchHasTags               = errors.New("branch has tags")
+	ErrCommitNotFound              = fmt.Errorf("commit %w", db.ErrNotFound)
+	ErrTagNotFound                 = fmt.Errorf("tag %w", db.ErrNotFound)
+	ErrTagAlreadyExists            = fmt.Errorf("tag %w", db.ErrAlreadyExists)
+	ErrRefNotFound                 = fmt.Errorf("ref %w", db.ErrNotFound)
+	ErrRefNotAncestor              = errors.New("ref is not an ancestor")
+	ErrCommitReferenced            = errors.New("commit is referenced")
+	ErrRepositoryNotFound          = fmt.Errorf("repository %w", db.ErrNotFound)
+	ErrMultipartUploadNotFound     = fmt.Errorf("multipart upload %w", db.ErrNotFound)
+	ErrInvalidMultipartUploadPart  = errors.New("invalid multipart upload part")
+	ErrEntryNotFound               = fmt.Errorf("entry %w", db.ErrNotFound)
+	ErrByteSliceTypeAssertion      = errors.New("type assertion to []byte failed")
+	ErrInvalidMetadataSrcFormat    = errors.New("invalid metadata src format")
+	ErrUnexpected                  = errors.New("unexpected error")
+	ErrReadEntryTimeout            = errors.New("read entry timeout")
+	ErrPreconditionFailed          = errors.New("precondition failed")
+	ErrChecksumMismatch            = errors.New("checksum mismatch")
 )
+
+// joinedError is err reported as an instance of kind: the message is that of err followed by kind, and errors.Is
+// and errors.As match both.
+type joinedError struct {
+	err  error
+	kind error
+}
+
+func joinErrors(err, kind error) error {
+	return &joinedError{err: err, kind: kind}
+}
+
+func (e *joinedError) Error() string {
+	return e.err.Error() + ": " + e.kind.Error()
+}
+
+func (e *joinedError) Unwrap() error {
+	return e.err
+}
+
+func (e *joinedError) Is(target error) bool {
+	return errors.Is(e.kind, target)
+}
+
+func (e *joinedError) As(target interface{}) bool {
+	return errors.As(e.kind, target)
+}
@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/logging"
+)
+
+// CommitEvent describes a successful commit to the hooks set by WithCommitHook
+type CommitEvent struct {
+	Repository string
+	Branch     string
+	// CommitLog is the new commit, its Reference identifies the commit
+	CommitLog *CommitLog
+	// Summary counts the paths changed by the commit by DifferenceType
+	Summary map[DifferenceType]int
+}
+
+// CommitHookFunc is called with every successful commit
+type CommitHookFunc func(ctx context.Context, event CommitEvent) error
+
+// PreCommitEvent describes a commit about to be stored to the hooks set by WithPreCommitHook
+type PreCommitEvent struct {
+	Repository string
+	Branch     string
+	Message    string
+	Committer  string
+	Metadata   Metadata
+	// Summary counts the paths the commit changes by DifferenceType
+	Summary map[DifferenceType]int
+	// Changes lists the changes the commit stores by path, with the size of each object, up to limit changes
+	// after path after.  It reads them in the commit transaction and can only be called during the hook call.
+	Changes func(limit int, after string) (Differences, bool, error)
+}
+
+// PreCommitHookFunc is called before every commit, an error fails the commit
+type PreCommitHookFunc func(ctx context.Context, event PreCommitEvent) error
+
+type commitHook struct {
+	fn         CommitHookFunc
+	failCommit bool
+}
+
+// runCommitHooks calls the commit hooks whose failCommit matches.  Hook errors are logged, and when failCommit is
+// set the first error is returned without calling the following hooks.
+func (c *cataloger) runCommitHooks(ctx context.Context, event CommitEvent, failCommit bool) error {
+	for _, hook := range c.commitHooks {
+		if hook.failCommit != failCommit {
+			continue
+		}
+		err := hook.fn(ctx, event)
+		if err == nil {
+			continue
+		}
+		c.log.WithError(err).WithFields(logging.Fields{
+			"repository":  event.Repository,
+			"branch":      event.Branch,
+			"reference":   event.CommitLog.Reference,
+			"fail_commit": failCommit,
+		}).Warn("commit hook failed")
+		if failCommit {
+			return fmt.Errorf("commit hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runPreCommitHooks calls the pre-commit hooks in order, and returns the first error
+func (c *cataloger) runPreCommitHooks(ctx context.Context, event PreCommitEvent) error {
+	for _, hook := range c.preCommitHooks {
+		if err := hook(ctx, event); err != nil {
+			return fmt.Errorf("pre-commit hook: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *cataloger) hasCommitHooks() bool {
+	return len(c.commitHooks) > 0 || len(c.preCommitHooks) > 0
+}
@@ -0,0 +1,20 @@
+package catalog
+
+import "time"
+
+// Diff operations reported to the MetricsRecorder
+const (
+	DiffOperationBranches          = "diff"
+	DiffOperationUncommitted       = "diff_uncommitted"
+	DiffOperationUncommittedStream = "diff_uncommitted_stream"
+)
+
+// MetricsRecorder is notified of every diff that completes successfully, with the time it took and the number of
+// differences it returned
+type MetricsRecorder interface {
+	RecordDiff(operation string, duration time.Duration, entries int)
+}
+
+type nopMetricsRecorder struct{}
+
+func (nopMetricsRecorder) RecordDiff(string, time.Duration, int) {}
@@ -3,11 +3,24 @@ package catalog
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
 type Metadata map[string]string
 
+// ChecksumAlgorithm identifies the algorithm of an additional entry checksum
+type ChecksumAlgorithm string
+
+const (
+	ChecksumAlgorithmSHA256 ChecksumAlgorithm = "sha256"
+	ChecksumAlgorithmCRC32C ChecksumAlgorithm = "crc32c"
+)
+
+// Checksums are additional checksums of an entry's content keyed by algorithm, base64 encoded as in S3
+// x-amz-checksum-* headers.  The entry Checksum remains the primary content checksum.
+type Checksums map[ChecksumAlgorithm]string
+
 type Repository struct {
 	Name             string    `db:"name"`
 	StorageNamespace string    `db:"storage_namespace"`
@@ -23,7 +36,12 @@ type Entry struct {
 	Size            int64     `db:"size"`
 	Checksum        string    `db:"checksum"`
 	Metadata        Metadata  `db:"metadata"`
+	Checksums       Checksums `db:"checksums"`
+	ContentType     string    `db:"content_type"`
 	Expired         bool      `db:"is_expired"`
+	// ExpiresAt is the lifecycle expiry of the entry, nil if it does not expire.  Unlike Expired, which is set
+	// when retention removed the object from storage, it only hides the entry from listings once past.
+	ExpiresAt *time.Time `db:"expires_at"`
 }
 
 type CommitLog struct {
@@ -38,6 +56,8 @@ type CommitLog struct {
 type MergeResult struct {
 	Summary   map[DifferenceType]int
 	Reference string
+	// Conflicts lists the paths in conflict, up to MergeMaxConflicts, when the merge fails with ErrConflictFound
+	Conflicts []string
 }
 
 type commitLogRaw struct {
@@ -62,12 +82,37 @@ type Branch struct {
 	Name       string `db:"name"`
 }
 
+// BranchTip is a branch with its last commit and whether it has uncommitted changes
+type BranchTip struct {
+	Name                  string
+	Reference             string
+	CreationDate          time.Time
+	HasUncommittedChanges bool
+}
+
+type branchTipRaw struct {
+	Name                  string    `db:"name"`
+	CommitID              CommitID  `db:"commit_id"`
+	CreationDate          time.Time `db:"creation_date"`
+	HasUncommittedChanges bool      `db:"has_uncommitted_changes"`
+}
+
 type MultipartUpload struct {
 	Repository      string    `db:"repository"`
 	UploadID        string    `db:"upload_id"`
 	Path            string    `db:"path"`
 	CreationDate    time.Time `db:"creation_date"`
 	PhysicalAddress string    `db:"physical_address"`
+	Metadata        Metadata  `db:"metadata"`
+	ContentType     string    `db:"content_type"`
+}
+
+type MultipartUploadPart struct {
+	PartNumber      int       `db:"part_number"`
+	ETag            string    `db:"etag"`
+	Size            int64     `db:"size"`
+	PhysicalAddress string    `db:"physical_address"`
+	CreationDate    time.Time `db:"creation_date"`
 }
 
 func (j Metadata) Value() (driver.Value, error) {
@@ -87,3 +132,31 @@ func (j *Metadata) Scan(src interface{}) error {
 	}
 	return json.Unmarshal(data, j)
 }
+
+// Verify returns ErrChecksumMismatch if any of the expected checksums is different or missing
+func (c Checksums) Verify(expected Checksums) error {
+	for algorithm, checksum := range expected {
+		if c[algorithm] != checksum {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, algorithm)
+		}
+	}
+	return nil
+}
+
+func (c Checksums) Value() (driver.Value, error) {
+	if c == nil {
+		return json.Marshal(struct{}{})
+	}
+	return json.Marshal(c)
+}
+
+func (c *Checksums) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	data, ok := src.([]byte)
+	if !ok {
+		return ErrByteSliceTypeAssertion
+	}
+	return json.Unmarshal(data, c)
+}
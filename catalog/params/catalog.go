@@ -5,8 +5,10 @@ import "time"
 type Cache struct {
 	Enabled bool
 	Size    int
-	Expiry  time.Duration
-	Jitter  time.Duration
+	// EntriesSize is the number of entries read from specific commits to cache, they are not cached when zero
+	EntriesSize int
+	Expiry      time.Duration
+	Jitter      time.Duration
 }
 
 type BatchRead struct {
@@ -21,8 +23,21 @@ type BatchWrite struct {
 	EntriesInsertSize int
 }
 
+type Repository struct {
+	// DeleteRetention is the period in which a deleted repository can be restored
+	DeleteRetention time.Duration
+}
+
+type Path struct {
+	// MaxLength is the maximum length of an entry path in bytes, the cataloger default is used when zero
+	MaxLength               int
+	RejectControlCharacters bool
+}
+
 type Catalog struct {
 	BatchRead  BatchRead
 	BatchWrite BatchWrite
 	Cache      Cache
+	Repository Repository
+	Path       Path
 }
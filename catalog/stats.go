@@ -1,6 +1,8 @@
 package catalog
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -19,3 +21,26 @@ var dedupRemoveObjectDroppedCounter = promauto.NewCounter(
 		Help: "A counter for dedup remove object that we dropped.",
 	},
 )
+
+var diffDurationHistograms = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "catalog_diff_duration_seconds",
+		Help: "Catalog diff durations",
+	},
+	[]string{"operation"})
+
+var diffEntriesHistograms = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "catalog_diff_entries",
+		Help:    "Catalog diff differences count histogram",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	},
+	[]string{"operation"})
+
+// DiffMetricsRecorder observes catalog diff durations and differences count by operation
+type DiffMetricsRecorder struct{}
+
+func (DiffMetricsRecorder) RecordDiff(operation string, duration time.Duration, entries int) {
+	diffDurationHistograms.WithLabelValues(operation).Observe(duration.Seconds())
+	diffEntriesHistograms.WithLabelValues(operation).Observe(float64(entries))
+}
@@ -4,26 +4,52 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/treeverse/lakefs/catalog/params"
 )
 
+// DefaultMaxPathLength is the maximum path length in bytes, matching the S3 key length limit
+const DefaultMaxPathLength = 1024
+
 var (
-	ErrInvalidValue = errors.New("invalid value")
+	ErrInvalidValue      = errors.New("invalid value")
+	ErrEmptyBranchName   = errors.New("empty branch name")
+	ErrInvalidBranchName = errors.New("invalid branch name")
 
-	validBranchNameRegexp     = regexp.MustCompile(`^\w[-\w]*$`)
+	// tag names are branch names that may also include dots, for version like tags
+	validTagNameRegexp        = regexp.MustCompile(`^\w([-.\w]*\w)?$`)
 	validRepositoryNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,62}$`)
+	// new repository names are used as bucket names by the S3 gateway, so they follow the (DNS based) bucket naming
+	// rules. Existing repositories may have names that do not, and remain valid.
+	validNewRepositoryNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,61}[a-z0-9]$`)
 )
 
 type ValidateFunc func() bool
 
+// ValidateCheckFunc returns an error describing why a value is invalid, nil if it is valid
+type ValidateCheckFunc func() error
+
+// ValidateField validates a value by IsValid, or by Check when set
 type ValidateField struct {
 	Name    string
 	IsValid ValidateFunc
+	Check   ValidateCheckFunc
 }
 
 type ValidateFields []ValidateField
 
+// Validate returns an ErrInvalidValue error naming the first invalid field.  The error of a failed Check is
+// matched by errors.Is as well.
 func Validate(validators ValidateFields) error {
 	for _, v := range validators {
+		if v.Check != nil {
+			if err := v.Check(); err != nil {
+				return joinErrors(fmt.Errorf("%w: %s", ErrInvalidValue, v.Name), err)
+			}
+			continue
+		}
 		if !v.IsValid() {
 			return fmt.Errorf("%w: %s", ErrInvalidValue, v.Name)
 		}
@@ -35,14 +61,45 @@ func IsNonEmptyString(s string) bool {
 	return len(s) > 0
 }
 
-func ValidateBranchName(branch string) ValidateFunc {
-	return func() bool {
-		return IsValidBranchName(branch)
+func ValidateBranchName(branch string) ValidateCheckFunc {
+	return func() error {
+		return CheckBranchName(branch)
 	}
 }
 
 func IsValidBranchName(branch string) bool {
-	return validBranchNameRegexp.MatchString(branch)
+	return CheckBranchName(branch) == nil
+}
+
+// CheckBranchName returns an error identifying the first offending character when branch is not a valid branch
+// name.  Branch names are made of ASCII letters, digits, '_' and '-', and do not start with '-'.  This excludes
+// the characters git rejects in refs (space, control characters, '/', '.', '~', '^', ':' and others), and keeps
+// branch names distinct from commit references, which start with CommitPrefix, and from committed references,
+// which end with CommittedSuffix.
+func CheckBranchName(branch string) error {
+	if branch == "" {
+		return ErrEmptyBranchName
+	}
+	for i, r := range branch {
+		switch {
+		case r == '-' && i == 0:
+			return fmt.Errorf("%w: name starts with '-'", ErrInvalidBranchName)
+		case r == '-', r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		default:
+			return fmt.Errorf("%w: character %q at position %d", ErrInvalidBranchName, r, i)
+		}
+	}
+	return nil
+}
+
+func ValidateTagName(tag string) ValidateFunc {
+	return func() bool {
+		return IsValidTagName(tag)
+	}
+}
+
+func IsValidTagName(tag string) bool {
+	return validTagNameRegexp.MatchString(tag)
 }
 
 func ValidateRepositoryName(repository string) ValidateFunc {
@@ -55,6 +112,18 @@ func IsValidRepositoryName(repository string) bool {
 	return validRepositoryNameRegexp.MatchString(repository)
 }
 
+func ValidateNewRepositoryName(repository string) ValidateFunc {
+	return func() bool {
+		return IsValidNewRepositoryName(repository)
+	}
+}
+
+// IsValidNewRepositoryName returns true if a repository may be created with the name, which must also be a valid
+// bucket name
+func IsValidNewRepositoryName(repository string) bool {
+	return validNewRepositoryNameRegexp.MatchString(repository) && !strings.Contains(repository, "--")
+}
+
 func ValidateReference(reference string) ValidateFunc {
 	return func() bool {
 		return IsValidReference(reference)
@@ -66,7 +135,8 @@ func IsValidReference(reference string) bool {
 	if err != nil {
 		return false
 	}
-	if !IsValidBranchName(ref.Branch) {
+	// a plain name may also be a tag
+	if !IsValidBranchName(ref.Branch) && !(ref.CommitID == UncommittedID && IsValidTagName(ref.Branch)) {
 		return false
 	}
 	if ref.CommitID < CommittedID {
@@ -81,12 +151,47 @@ func ValidateUploadID(uploadID string) ValidateFunc {
 	}
 }
 
+func ValidateMultipartPartNumber(partNumber int) ValidateFunc {
+	return func() bool {
+		return partNumber >= 1 && partNumber <= MultipartUploadMaxParts
+	}
+}
+
+// ValidatePath validates that path is not empty.  The path limits a cataloger is configured with are validated
+// by ValidatePathLimits.
 func ValidatePath(name string) ValidateFunc {
 	return func() bool {
 		return IsNonEmptyString(name)
 	}
 }
 
+func ValidatePathLimits(path string, limits params.Path) ValidateFunc {
+	return func() bool {
+		return IsValidPathLimits(path, limits)
+	}
+}
+
+// IsValidPathLimits returns true if path is not empty and within limits.  Paths are limited to
+// limits.MaxLength bytes, DefaultMaxPathLength when it is not positive, and may not include control characters
+// when limits.RejectControlCharacters is set.
+func IsValidPathLimits(path string, limits params.Path) bool {
+	maxLength := limits.MaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxPathLength
+	}
+	if !IsNonEmptyString(path) || len(path) > maxLength {
+		return false
+	}
+	if limits.RejectControlCharacters {
+		for _, r := range path {
+			if unicode.IsControl(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func ValidatePhysicalAddress(addr string) ValidateFunc {
 	return func() bool {
 		return IsNonEmptyString(addr)
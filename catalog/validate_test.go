@@ -1,7 +1,11 @@
 package catalog
 
 import (
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/treeverse/lakefs/catalog/params"
 )
 
 func TestValidate(t *testing.T) {
@@ -75,6 +79,17 @@ func TestIsValidBranchName(t *testing.T) {
 		{name: "leading-dash", input: "-branch", want: false},
 		{name: "underscores", input: "__", want: true},
 		{name: "backslash", input: "a\\branch", want: false},
+		{name: "digits", input: "123", want: true},
+		{name: "trailing-slash", input: "branch/", want: false},
+		{name: "nested", input: "feature/branch", want: false},
+		{name: "dots", input: "a..b", want: false},
+		{name: "tilde", input: "a~1", want: false},
+		{name: "caret", input: "a^", want: false},
+		{name: "colon", input: "a:b", want: false},
+		{name: "control", input: "a\tb", want: false},
+		{name: "non-ascii", input: "br\u00e4nch", want: false},
+		{name: "commit reference", input: MakeReference("main", 3), want: false},
+		{name: "committed reference", input: MakeReference("main", CommittedID), want: false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -86,6 +101,77 @@ func TestIsValidBranchName(t *testing.T) {
 	}
 }
 
+func TestCheckBranchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "valid", input: "feature-1_a"},
+		{name: "empty", input: "", wantErr: ErrEmptyBranchName, wantMsg: "empty branch name"},
+		{name: "space", input: "got space", wantErr: ErrInvalidBranchName, wantMsg: "invalid branch name: character ' ' at position 3"},
+		{name: "control", input: "a\x01", wantErr: ErrInvalidBranchName, wantMsg: `invalid branch name: character '\x01' at position 1`},
+		{name: "leading-slash", input: "/branch", wantErr: ErrInvalidBranchName, wantMsg: "invalid branch name: character '/' at position 0"},
+		{name: "dots", input: "a..b", wantErr: ErrInvalidBranchName, wantMsg: "invalid branch name: character '.' at position 1"},
+		{name: "tilde", input: "~branch", wantErr: ErrInvalidBranchName, wantMsg: "invalid branch name: character '~' at position 0"},
+		{name: "committed suffix", input: "main" + CommittedSuffix, wantErr: ErrInvalidBranchName, wantMsg: "invalid branch name: character ':' at position 4"},
+		{name: "leading-dash", input: "-branch", wantErr: ErrInvalidBranchName, wantMsg: "invalid branch name: name starts with '-'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckBranchName(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckBranchName() err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.wantMsg {
+				t.Errorf("CheckBranchName() err = %s, want %s", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidate_BranchName(t *testing.T) {
+	err := Validate(ValidateFields{{Name: "branch", Check: ValidateBranchName("a^b")}})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("Validate() err = %v, want %v", err, ErrInvalidValue)
+	}
+	if !errors.Is(err, ErrInvalidBranchName) {
+		t.Fatalf("Validate() err = %v, want %v", err, ErrInvalidBranchName)
+	}
+	const expected = "invalid value: branch: invalid branch name: character '^' at position 1"
+	if err.Error() != expected {
+		t.Errorf("Validate() err = %s, want %s", err, expected)
+	}
+}
+
+func TestIsValidTagName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "simple", input: "tag", want: true},
+		{name: "empty", input: "", want: false},
+		{name: "short", input: "a", want: true},
+		{name: "version", input: "v1.0.2", want: true},
+		{name: "dash", input: "release-1", want: true},
+		{name: "space", input: "got space", want: false},
+		{name: "special", input: "/tag", want: false},
+		{name: "leading-dot", input: ".tag", want: false},
+		{name: "trailing-dot", input: "tag.", want: false},
+		{name: "commit prefix", input: "~tag", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsValidTagName(tt.input)
+			if got != tt.want {
+				t.Errorf("IsValidTagName() got = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsNonEmptyString(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -105,3 +191,96 @@ func TestIsNonEmptyString(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidPathLimits(t *testing.T) {
+	tests := []struct {
+		name                    string
+		maxLength               int
+		rejectControlCharacters bool
+		input                   string
+		want                    bool
+	}{
+		{name: "simple", input: "path/to/object", want: true},
+		{name: "empty", input: "", want: false},
+		{name: "max length", input: strings.Repeat("a", DefaultMaxPathLength), want: true},
+		{name: "over max length", input: strings.Repeat("a", DefaultMaxPathLength+1), want: false},
+		{name: "multibyte over max length", input: strings.Repeat("é", DefaultMaxPathLength/2) + "a", want: false},
+		{name: "custom max length", maxLength: 10, input: strings.Repeat("a", 10), want: true},
+		{name: "over custom max length", maxLength: 10, input: strings.Repeat("a", 11), want: false},
+		{name: "control characters allowed", input: "path\x00/to\nobject", want: true},
+		{name: "control characters rejected", rejectControlCharacters: true, input: "path/to\nobject", want: false},
+		{name: "nul rejected", rejectControlCharacters: true, input: "path\x00", want: false},
+		{name: "del rejected", rejectControlCharacters: true, input: "path\x7f", want: false},
+		{name: "unicode when rejecting control characters", rejectControlCharacters: true, input: "path/\u05d0\u00e9", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := params.Path{MaxLength: tt.maxLength, RejectControlCharacters: tt.rejectControlCharacters}
+			if got := IsValidPathLimits(tt.input, limits); got != tt.want {
+				t.Errorf("IsValidPathLimits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidRepositoryName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "simple", input: "repo", want: true},
+		{name: "empty", input: "", want: false},
+		{name: "min length", input: "abc", want: true},
+		{name: "too short", input: "ab", want: false},
+		{name: "max length", input: strings.Repeat("a", 63), want: true},
+		{name: "too long", input: strings.Repeat("a", 64), want: false},
+		{name: "hyphen", input: "my-repo-1", want: true},
+		{name: "uppercase", input: "MyRepo", want: false},
+		{name: "leading hyphen", input: "-repo", want: false},
+		{name: "trailing hyphen", input: "repo-", want: true},
+		{name: "consecutive hyphens", input: "my--repo", want: true},
+		{name: "dot", input: "my.repo", want: false},
+		{name: "underscore", input: "my_repo", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidRepositoryName(tt.input); got != tt.want {
+				t.Errorf("IsValidRepositoryName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidNewRepositoryName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "simple", input: "repo", want: true},
+		{name: "empty", input: "", want: false},
+		{name: "min length", input: "abc", want: true},
+		{name: "too short", input: "ab", want: false},
+		{name: "max length", input: strings.Repeat("a", 63), want: true},
+		{name: "too long", input: strings.Repeat("a", 64), want: false},
+		{name: "digits", input: "123", want: true},
+		{name: "hyphen", input: "my-repo-1", want: true},
+		{name: "uppercase", input: "MyRepo", want: false},
+		{name: "leading hyphen", input: "-repo", want: false},
+		{name: "trailing hyphen", input: "repo-", want: false},
+		{name: "consecutive hyphens", input: "my--repo", want: false},
+		{name: "punycode prefix", input: "xn--repo", want: false},
+		{name: "dot", input: "my.repo", want: false},
+		{name: "underscore", input: "my_repo", want: false},
+		{name: "ip address", input: "192.168.5.4", want: false},
+		{name: "space", input: "my repo", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidNewRepositoryName(tt.input); got != tt.want {
+				t.Errorf("IsValidNewRepositoryName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
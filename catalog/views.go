@@ -54,7 +54,7 @@ func sqEntriesLineage(branchID int64, requestedCommit CommitID, lineage []lineag
 		Columns(strconv.FormatInt(branchID, 10)+" AS displayed_branch",
 			"e.path", "e.branch_id AS source_branch",
 			"e.min_commit", "e.physical_address",
-			"e.creation_date", "e.size", "e.checksum", "e.metadata",
+			"e.creation_date", "e.size", "e.checksum", "e.metadata", "e.checksums", "e.content_type", "e.expires_at",
 			"e.is_committed", "e.is_tombstone", "e.entry_ctid", "e.is_expired").
 		Column(maxCommitAlias).Column(isDeletedAlias)
 	return baseSelect
@@ -91,7 +91,7 @@ func sqEntriesLineageV(branchID int64, requestedCommit CommitID, lineage []linea
 		Column("? AS displayed_branch", strconv.FormatInt(branchID, 10)).
 		Columns("e.path", "e.branch_id AS source_branch",
 			"e.min_commit", "e.physical_address",
-			"e.creation_date", "e.size", "e.checksum", "e.metadata",
+			"e.creation_date", "e.size", "e.checksum", "e.metadata", "e.checksums", "e.content_type", "e.expires_at",
 			"e.is_committed", "e.is_tombstone", "e.entry_ctid", "e.is_expired").
 		Column(maxCommitAlias).Column(isDeletedAlias)
 	return baseSelect
@@ -100,7 +100,7 @@ var branchCreateCmd = &cobra.Command{
 
 var branchDeleteCmd = &cobra.Command{
 	Use:   "delete <branch uri>",
-	Short: "delete a branch in a repository, along with its uncommitted changes (CAREFUL)",
+	Short: "delete a branch in a repository, use --force to delete it along with its uncommitted changes (CAREFUL)",
 	Args: cmdutils.ValidationChain(
 		cobra.ExactArgs(1),
 		cmdutils.FuncValidator(0, uri.ValidateRefURI),
@@ -110,9 +110,10 @@ var branchDeleteCmd = &cobra.Command{
 		if err != nil || !confirmation {
 			Die("Delete branch aborted", 1)
 		}
+		force, _ := cmd.Flags().GetBool("force")
 		client := getClient()
 		u := uri.Must(uri.Parse(args[0]))
-		err = client.DeleteBranch(context.Background(), u.Repository, u.Ref)
+		err = client.DeleteBranch(context.Background(), u.Repository, u.Ref, force)
 		if err != nil {
 			DieErr(err)
 		}
@@ -222,6 +223,8 @@ func init() {
 	branchCreateCmd.Flags().StringP("source", "s", "", "source branch uri")
 	_ = branchCreateCmd.MarkFlagRequired("source")
 
+	branchDeleteCmd.Flags().Bool("force", false, "delete the branch even if it has uncommitted changes")
+
 	branchRevertCmd.Flags().String("commit", "", "commit ID to revert branch to")
 	branchRevertCmd.Flags().String("prefix", "", "prefix of the objects to be reverted")
 	branchRevertCmd.Flags().String("object", "", "path to object to be reverted")
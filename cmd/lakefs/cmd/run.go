@@ -23,6 +23,7 @@ import (
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/dedup"
 	"github.com/treeverse/lakefs/gateway"
+	"github.com/treeverse/lakefs/gateway/sig"
 	"github.com/treeverse/lakefs/gateway/simulator"
 	"github.com/treeverse/lakefs/httputil"
 	"github.com/treeverse/lakefs/logging"
@@ -33,6 +34,8 @@ import (
 const (
 	gracefulShutdownTimeout = 30 * time.Second
 
+	purgeDeletedRepositoriesInterval = time.Hour
+
 	serviceAPIServer = "api"
 	serviceS3Gateway = "s3gateway"
 )
@@ -62,7 +65,9 @@ var runCmd = &cobra.Command{
 		migrator := db.NewDatabaseMigrator(dbParams)
 
 		// init catalog
-		cataloger := catalog.NewCataloger(dbPool, catalog.WithParams(conf.GetCatalogerCatalogParams()))
+		cataloger := catalog.NewCataloger(dbPool,
+			catalog.WithParams(conf.GetCatalogerCatalogParams()),
+			catalog.WithMetricsRecorder(catalog.DiffMetricsRecorder{}))
 
 		// init block store
 		blockStore, err := factory.BuildBlockAdapter(cfg)
@@ -115,6 +120,27 @@ var runCmd = &cobra.Command{
 		)
 
 		// init gateway server
+		sigConfig := sig.Config{
+			ForwardedHostHeaders: cfg.GetS3GatewayTrustedForwardedHostHeaders(),
+			MetricsRecorder:      gateway.SigMetricsRecorder{},
+		}
+		if pattern := cfg.GetS3GatewayAccessKeyIDPattern(); pattern != "" {
+			accessKeyIDPattern, err := sig.NewAccessKeyIDPattern(pattern)
+			if err != nil {
+				logger.WithError(err).Fatal("Invalid S3 gateway access key id pattern")
+			}
+			sigConfig.AccessKeyIDPattern = accessKeyIDPattern
+		}
+		allowedRegions := cfg.GetS3GatewayAllowedRegions()
+		repositoryAllowedRegions := cfg.GetS3GatewayRepositoryAllowedRegions()
+		if len(allowedRegions) > 0 || len(repositoryAllowedRegions) > 0 {
+			sigConfig.AllowedRegions = func(repository string) []string {
+				if regions, ok := repositoryAllowedRegions[repository]; ok {
+					return regions
+				}
+				return allowedRegions
+			}
+		}
 		s3gatewayHandler := gateway.NewHandler(
 			cfg.GetS3GatewayRegion(),
 			cataloger,
@@ -123,10 +149,12 @@ var runCmd = &cobra.Command{
 			cfg.GetS3GatewayDomainName(),
 			stats,
 			dedupCleaner,
+			sigConfig,
 		)
 
 		ctx, cancelFn := context.WithCancel(context.Background())
 		go stats.Run(ctx)
+		go purgeDeletedRepositories(ctx, cataloger)
 
 		stats.CollectEvent("global", "run")
 
@@ -181,6 +209,25 @@ func printWelcome(w io.Writer) {
 	fmt.Fprint(w, runBanner)
 }
 
+// purgeDeletedRepositories periodically removes the repositories deleted before the delete retention period
+func purgeDeletedRepositories(ctx context.Context, cataloger catalog.Cataloger) {
+	ticker := time.NewTicker(purgeDeletedRepositoriesInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := cataloger.PurgeDeletedRepositories(ctx)
+			if err != nil {
+				logging.Default().WithError(err).Error("failed to purge deleted repositories")
+			} else if purged > 0 {
+				logging.Default().WithField("repositories", purged).Info("purged deleted repositories")
+			}
+		}
+	}
+}
+
 func registerPrometheusCollector(db sqlstats.StatsGetter) {
 	collector := sqlstats.NewStatsCollector("lakefs", db)
 	err := prometheus.Register(collector)
@@ -129,10 +129,18 @@ func (c *Config) GetCatalogerCatalogParams() catalogparams.Catalog {
 			EntriesInsertSize: viper.GetInt("cataloger.batch_write.insert_size"),
 		},
 		Cache: catalogparams.Cache{
-			Enabled: viper.GetBool("cataloger.cache.enabled"),
-			Size:    viper.GetInt("cataloger.cache.size"),
-			Expiry:  viper.GetDuration("cataloger.cache.expiry"),
-			Jitter:  viper.GetDuration("cataloger.cache.jitter"),
+			Enabled:     viper.GetBool("cataloger.cache.enabled"),
+			Size:        viper.GetInt("cataloger.cache.size"),
+			EntriesSize: viper.GetInt("cataloger.cache.entries_size"),
+			Expiry:      viper.GetDuration("cataloger.cache.expiry"),
+			Jitter:      viper.GetDuration("cataloger.cache.jitter"),
+		},
+		Repository: catalogparams.Repository{
+			DeleteRetention: viper.GetDuration("cataloger.repository.delete_retention"),
+		},
+		Path: catalogparams.Path{
+			MaxLength:               viper.GetInt("cataloger.path.max_length"),
+			RejectControlCharacters: viper.GetBool("cataloger.path.reject_control_characters"),
 		},
 	}
 }
@@ -282,6 +290,22 @@ func (c *Config) GetS3GatewayDomainName() string {
 	return viper.GetString("gateways.s3.domain_name")
 }
 
+func (c *Config) GetS3GatewayAccessKeyIDPattern() string {
+	return viper.GetString("gateways.s3.access_key_id_pattern")
+}
+
+func (c *Config) GetS3GatewayTrustedForwardedHostHeaders() []string {
+	return viper.GetStringSlice("gateways.s3.trusted_forwarded_host_headers")
+}
+
+func (c *Config) GetS3GatewayAllowedRegions() []string {
+	return viper.GetStringSlice("gateways.s3.allowed_regions")
+}
+
+func (c *Config) GetS3GatewayRepositoryAllowedRegions() map[string][]string {
+	return viper.GetStringMapStringSlice("gateways.s3.repository_allowed_regions")
+}
+
 func (c *Config) GetListenAddress() string {
 	return viper.GetString("listen_address")
 }
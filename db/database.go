@@ -139,15 +139,26 @@ func (d *SqlxDatabase) Transact(fn TxFunc, opts ...TxOpt) (interface{}, error) {
 	}
 	var attempt int
 	var ret interface{}
+	// retryErr is the retryable error that failed the last attempt
+	var retryErr error
 	for attempt < SerializationRetryMaxAttempts {
+		// do not retry (or start) a transaction for a canceled request
+		if err := options.ctx.Err(); err != nil {
+			return nil, err
+		}
 		if attempt > 0 {
-			duration := time.Duration(int(SerializationRetryStartInterval) * attempt)
+			duration := retryInterval(attempt)
 			dbRetriesCount.Inc()
 			options.logger.
 				WithField("attempt", attempt).
 				WithField("sleep_interval", duration).
-				Warn("retrying transaction due to serialization error")
-			time.Sleep(duration)
+				WithError(retryErr).
+				Warn("retrying transaction due to " + retryableErrorReason(retryErr))
+			select {
+			case <-options.ctx.Done():
+				return nil, options.ctx.Err()
+			case <-time.After(duration):
+			}
 		}
 
 		tx, err := d.db.BeginTxx(options.ctx, &sql.TxOptions{
@@ -157,15 +168,15 @@ func (d *SqlxDatabase) Transact(fn TxFunc, opts ...TxOpt) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		ret, err = fn(&dbTx{tx: tx, logger: options.logger})
+		ret, err = fn(&dbTx{tx: tx, ctx: options.ctx, logger: options.logger})
 		if err != nil {
 			rollbackErr := tx.Rollback()
 			if rollbackErr != nil {
 				return nil, rollbackErr
 			}
-			// retry on serialization error
-			if IsSerializationError(err) {
-				// retry
+			// retry on serialization error or deadlock
+			if isRetryableError(err) {
+				retryErr = err
 				attempt++
 				continue
 			}
@@ -173,8 +184,9 @@ func (d *SqlxDatabase) Transact(fn TxFunc, opts ...TxOpt) (interface{}, error) {
 		} else {
 			err = tx.Commit()
 			if err != nil {
-				// retry on serialization error
-				if IsSerializationError(err) {
+				// retry on serialization error or deadlock
+				if isRetryableError(err) {
+					retryErr = err
 					attempt++
 					continue
 				}
@@ -188,11 +200,24 @@ func (d *SqlxDatabase) Transact(fn TxFunc, opts ...TxOpt) (interface{}, error) {
 	if attempt == SerializationRetryMaxAttempts {
 		options.logger.
 			WithField("attempt", attempt).
-			Warn("transaction failed after max attempts due to serialization error")
+			WithError(retryErr).
+			Warn("transaction failed after max attempts due to " + retryableErrorReason(retryErr))
 	}
 	return nil, ErrSerialization
 }
 
+// retryInterval returns the time to wait before a retry attempt, doubling with every attempt up to a maximum
+func retryInterval(attempt int) time.Duration {
+	interval := SerializationRetryStartInterval
+	for i := 1; i < attempt && interval < SerializationRetryMaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > SerializationRetryMaxInterval {
+		interval = SerializationRetryMaxInterval
+	}
+	return interval
+}
+
 func (d *SqlxDatabase) Metadata() (map[string]string, error) {
 	metadata := make(map[string]string)
 	version, err := d.getVersion()
@@ -0,0 +1,59 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/db/params"
+)
+
+func TestTransactRetry(t *testing.T) {
+	database, err := db.ConnectDB(params.Database{Driver: "pgx", ConnectionString: databaseURI})
+	if err != nil {
+		t.Fatal("connect db:", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	errOther := errors.New("other error")
+	tests := []struct {
+		name      string
+		failures  int
+		failWith  error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "no failure", wantCalls: 1},
+		{name: "serialization failure", failures: 1, failWith: &pgconn.PgError{Code: pgerrcode.SerializationFailure}, wantCalls: 2},
+		{name: "deadlock", failures: 2, failWith: &pgconn.PgError{Code: pgerrcode.DeadlockDetected}, wantCalls: 3},
+		{name: "max attempts", failures: db.SerializationRetryMaxAttempts, failWith: &pgconn.PgError{Code: pgerrcode.SerializationFailure}, wantCalls: db.SerializationRetryMaxAttempts, wantErr: db.ErrSerialization},
+		{name: "not retryable", failures: 1, failWith: errOther, wantCalls: 1, wantErr: errOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			res, err := database.Transact(func(tx db.Tx) (interface{}, error) {
+				calls++
+				if calls <= tt.failures {
+					return nil, tt.failWith
+				}
+				var n int
+				if err := tx.Get(&n, `SELECT 1`); err != nil {
+					return nil, err
+				}
+				return n, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transact() err = %v, expected %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("Transact() called fn %d times, expected %d", calls, tt.wantCalls)
+			}
+			if err == nil && res.(int) != 1 {
+				t.Fatalf("Transact() = %v, expected 1", res)
+			}
+		})
+	}
+}
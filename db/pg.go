@@ -11,6 +11,23 @@ func IsSerializationError(err error) bool {
 	return isPGCode(err, pgerrcode.SerializationFailure)
 }
 
+func IsDeadlockError(err error) bool {
+	return isPGCode(err, pgerrcode.DeadlockDetected)
+}
+
+// isRetryableError returns true for errors caused by concurrent transactions, retrying the transaction may succeed
+func isRetryableError(err error) bool {
+	return IsSerializationError(err) || IsDeadlockError(err)
+}
+
+// retryableErrorReason describes the retryable error err for logging
+func retryableErrorReason(err error) string {
+	if IsDeadlockError(err) {
+		return "deadlock"
+	}
+	return "serialization error"
+}
+
 func IsUniqueViolation(err error) bool {
 	return isPGCode(err, pgerrcode.UniqueViolation)
 }
@@ -14,6 +14,7 @@ import (
 const (
 	SerializationRetryMaxAttempts   = 10
 	SerializationRetryStartInterval = time.Millisecond * 2
+	SerializationRetryMaxInterval   = time.Millisecond * 500
 )
 
 type Tx interface {
@@ -25,6 +26,7 @@ type Tx interface {
 
 type dbTx struct {
 	tx     *sqlx.Tx
+	ctx    context.Context
 	logger logging.Logger
 }
 
@@ -34,7 +36,7 @@ func queryToString(q string) string {
 
 func (d *dbTx) Query(query string, args ...interface{}) (*sqlx.Rows, error) {
 	start := time.Now()
-	rows, err := d.tx.Queryx(query, args...)
+	rows, err := d.tx.QueryxContext(d.ctx, query, args...)
 	log := d.logger.WithFields(logging.Fields{
 		"type":  "query",
 		"args":  args,
@@ -51,7 +53,7 @@ func (d *dbTx) Query(query string, args ...interface{}) (*sqlx.Rows, error) {
 
 func (d *dbTx) Select(dest interface{}, query string, args ...interface{}) error {
 	start := time.Now()
-	err := d.tx.Select(dest, query, args...)
+	err := d.tx.SelectContext(d.ctx, dest, query, args...)
 	log := d.logger.WithFields(logging.Fields{
 		"type":  "select",
 		"args":  args,
@@ -69,7 +71,7 @@ func (d *dbTx) Select(dest interface{}, query string, args ...interface{}) error
 
 func (d *dbTx) Get(dest interface{}, query string, args ...interface{}) error {
 	start := time.Now()
-	err := d.tx.Get(dest, query, args...)
+	err := d.tx.GetContext(d.ctx, dest, query, args...)
 	log := d.logger.WithFields(logging.Fields{
 		"type":  "get",
 		"args":  args,
@@ -91,7 +93,7 @@ func (d *dbTx) Get(dest interface{}, query string, args ...interface{}) error {
 
 func (d *dbTx) Exec(query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
-	res, err := d.tx.Exec(query, args...)
+	res, err := d.tx.ExecContext(d.ctx, query, args...)
 	log := d.logger.WithFields(logging.Fields{
 		"type":  "exec",
 		"args":  args,
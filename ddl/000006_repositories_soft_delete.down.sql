@@ -0,0 +1,4 @@
+BEGIN;
+DELETE FROM catalog_repositories WHERE deleted_at IS NOT NULL;
+ALTER TABLE catalog_repositories DROP COLUMN IF EXISTS deleted_at;
+COMMIT;
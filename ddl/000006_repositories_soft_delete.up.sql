@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE catalog_repositories ADD COLUMN deleted_at timestamp with time zone;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE catalog_entries ADD COLUMN checksums jsonb;
+COMMIT;
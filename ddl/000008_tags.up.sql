@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE catalog_tags (
+    repository_id integer NOT NULL,
+    name character varying COLLATE "C" NOT NULL,
+    branch_id bigint NOT NULL,
+    commit_id bigint NOT NULL,
+    creation_date timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT catalog_tags_pk PRIMARY KEY (repository_id, name),
+    CONSTRAINT catalog_tags_repository_id_fk FOREIGN KEY (repository_id) REFERENCES catalog_repositories(id) ON DELETE CASCADE,
+    CONSTRAINT catalog_tags_branch_id_fk FOREIGN KEY (branch_id) REFERENCES catalog_branches(id) ON DELETE CASCADE
+);
+COMMIT;
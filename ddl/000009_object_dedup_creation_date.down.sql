@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE catalog_object_dedup DROP COLUMN IF EXISTS creation_date;
+COMMIT;
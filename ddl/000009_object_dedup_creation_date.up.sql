@@ -0,0 +1,4 @@
+BEGIN;
+-- existing objects are dated to the migration, so they only become garbage collection candidates for later cutoffs
+ALTER TABLE catalog_object_dedup ADD COLUMN IF NOT EXISTS creation_date timestamp with time zone DEFAULT now() NOT NULL;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE IF EXISTS catalog_multipart_upload_parts;
+COMMIT;
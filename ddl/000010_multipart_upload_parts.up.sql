@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE catalog_multipart_upload_parts (
+    upload_id character varying NOT NULL,
+    part_number integer NOT NULL,
+    etag character varying NOT NULL,
+    size bigint NOT NULL,
+    physical_address character varying NOT NULL,
+    creation_date timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT catalog_multipart_upload_parts_pk PRIMARY KEY (upload_id, part_number),
+    CONSTRAINT catalog_multipart_upload_parts_upload_id_fk FOREIGN KEY (upload_id) REFERENCES catalog_multipart_uploads(upload_id) ON DELETE CASCADE
+);
+COMMIT;
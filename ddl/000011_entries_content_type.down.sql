@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE catalog_entries DROP COLUMN IF EXISTS content_type;
+COMMIT;
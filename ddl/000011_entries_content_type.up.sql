@@ -0,0 +1,4 @@
+BEGIN;
+-- existing entries keep an empty content type, readers guess it from the path
+ALTER TABLE catalog_entries ADD COLUMN content_type character varying DEFAULT '' NOT NULL;
+COMMIT;
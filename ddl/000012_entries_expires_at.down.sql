@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX IF EXISTS catalog_entries_expires_at_idx;
+ALTER TABLE catalog_entries DROP COLUMN IF EXISTS expires_at;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+-- entries without an expiry never expire
+ALTER TABLE catalog_entries ADD COLUMN expires_at timestamptz;
+CREATE INDEX IF NOT EXISTS catalog_entries_expires_at_idx ON catalog_entries (expires_at) WHERE expires_at IS NOT NULL;
+COMMIT;
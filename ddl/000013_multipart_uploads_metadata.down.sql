@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE catalog_multipart_uploads DROP COLUMN IF EXISTS content_type;
+ALTER TABLE catalog_multipart_uploads DROP COLUMN IF EXISTS metadata;
+COMMIT;
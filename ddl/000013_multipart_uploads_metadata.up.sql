@@ -0,0 +1,5 @@
+BEGIN;
+-- metadata and content type are given when the upload is created and applied to the entry on complete
+ALTER TABLE catalog_multipart_uploads ADD COLUMN metadata jsonb;
+ALTER TABLE catalog_multipart_uploads ADD COLUMN content_type character varying DEFAULT '' NOT NULL;
+COMMIT;
@@ -51,6 +51,7 @@ const (
 	ErrNone APIErrorCode = iota
 	ErrAccessDenied
 	ErrBadDigest
+	ErrBadChecksum
 	ErrEntityTooSmall
 	ErrEntityTooLarge
 	ErrPolicyTooLarge
@@ -102,6 +103,7 @@ const (
 	ErrCredMalformed
 	ErrInvalidRegion
 	ErrInvalidService
+	ErrWrongService
 	ErrInvalidRequestVersion
 	ErrMissingSignTag
 	ErrMissingSignHeadersTag
@@ -129,6 +131,7 @@ const (
 	ErrBadRequest
 	ErrKeyTooLongError
 	ErrInvalidAPIVersion
+	ErrMissingSignedHeader
 	// Add new error codes here.
 
 	// SSE-S3 related API errors
@@ -251,6 +254,11 @@ var Codes = errorCodeMap{
 		Description:    "The Content-Md5 you specified did not match what we received.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrBadChecksum: {
+		Code:           "BadDigest",
+		Description:    "The checksum you specified did not match what we received.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrEntityTooSmall: {
 		Code:           "EntityTooSmall",
 		Description:    "Your proposed upload is smaller than the minimum allowed object size.",
@@ -480,6 +488,11 @@ var Codes = errorCodeMap{
 		Description:    "Error parsing the X-Amz-Credential parameter; incorrect service. This endpoint belongs to \"s3\".",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrWrongService: {
+		Code:           "AccessDenied",
+		Description:    "Request is signed for a different service. This endpoint belongs to \"s3\".",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	// FIXME: Should contain the invalid param set as seen in https://github.com/minio/minio/issues/2385.
 	// Description:   "Error parsing the X-Amz-Credential parameter; incorrect terminal "aws4_reque". This endpoint uses "aws4_request".
 	// Need changes to make sure variable messages can be constructed.
@@ -559,6 +572,11 @@ var Codes = errorCodeMap{
 		Description:    "There were headers present in the request which were not signed",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrMissingSignedHeader: {
+		Code:           "AccessDenied",
+		Description:    "There were headers in the signed headers list which were not present in the request",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrInvalidQueryParams: {
 		Code:           "AuthorizationQueryParametersError",
 		Description:    "Query-string authentication version 4 requires the X-Amz-Algorithm, X-Amz-Credential, X-Amz-Signature, X-Amz-Date, X-Amz-SignedHeaders, and X-Amz-Expires parameters.",
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/treeverse/lakefs/auth"
+	"github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
@@ -42,9 +43,13 @@ type ServerContext struct {
 	authService  simulator.GatewayAuthService
 	stats        stats.Collector
 	dedupCleaner *dedup.Cleaner
+	sigConfig    sig.Config
 }
 
-const operationIDNotFound = "not_found_operation"
+const (
+	operationIDNotFound = "not_found_operation"
+	s3Service           = "s3"
+)
 
 func (c *ServerContext) WithContext(ctx context.Context) *ServerContext {
 	return &ServerContext{
@@ -67,6 +72,7 @@ func NewHandler(
 	bareDomain string,
 	stats stats.Collector,
 	dedupCleaner *dedup.Cleaner,
+	sigConfig sig.Config,
 ) http.Handler {
 	sc := &ServerContext{
 		ctx:          context.Background(),
@@ -77,6 +83,7 @@ func NewHandler(
 		authService:  authService,
 		stats:        stats,
 		dedupCleaner: dedupCleaner,
+		sigConfig:    sigConfig,
 	}
 
 	// setup routes
@@ -100,15 +107,27 @@ func NewHandler(
 }
 
 func getAPIErrOrDefault(err error, defaultAPIErr gatewayerrors.APIErrorCode) gatewayerrors.APIError {
-	apiError, ok := err.(*gatewayerrors.APIErrorCode)
-	if ok {
+	var apiError gatewayerrors.APIErrorCode
+	if errors.As(err, &apiError) {
 		return apiError.ToAPIErr()
-	} else {
-		return defaultAPIErr.ToAPIErr()
 	}
+	return defaultAPIErr.ToAPIErr()
+}
+
+func (c *ServerContext) lookupCredentials(ctx context.Context, accessKeyID string) (*model.Credential, error) {
+	creds, err := c.authService.GetCredentials(accessKeyID)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, sig.ErrAccessKeyNotFound
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("key", accessKeyID).Warn("error getting access key")
+		return nil, gatewayerrors.ErrInternalError
+	}
+	return creds, nil
 }
 
-func authenticateOperation(s *ServerContext, writer http.ResponseWriter, request *http.Request, perms []permissions.Permission) *operations.AuthenticatedOperation {
+// authenticateOperation authenticates and authorizes request, addressed to repoID unless it is empty
+func authenticateOperation(s *ServerContext, writer http.ResponseWriter, request *http.Request, repoID string, perms []permissions.Permission) *operations.AuthenticatedOperation {
 	o := &operations.Operation{
 		Request:        request,
 		ResponseWriter: writer,
@@ -128,35 +147,50 @@ func authenticateOperation(s *ServerContext, writer http.ResponseWriter, request
 	}
 
 	// authenticate
-	authenticator := sig.ChainedAuthenticator(
-		sig.NewV4Authenticator(request),
-		sig.NewV2SigAuthenticator(request))
+	authenticator := sig.NewAuthenticator(request, s.sigConfig)
+	// the regions requests may be signed for can depend on the repository
+	switch a := authenticator.(type) {
+	case *sig.V4Authenticator:
+		a.Repository = repoID
+		if simulator.PlaybackParams.IsPlayback {
+			// recorded requests are replayed long after they were signed
+			a.MaxClockSkew = 0
+		}
+	case *sig.V2SigAuthenticator:
+		a.Repository = repoID
+	}
 
-	authContext, err := authenticator.Parse()
-	if err != nil {
-		o.Log().WithError(err).Warn("failed to parse signature")
-		o.EncodeError(getAPIErrOrDefault(err, gatewayerrors.ErrAccessDenied))
-		return nil
+	// log lookup failures with the request id
+	lookupCredentials := func(accessKeyID string) (*model.Credential, error) {
+		return s.lookupCredentials(request.Context(), accessKeyID)
 	}
-	creds, err := s.authService.GetCredentials(authContext.GetAccessKeyID())
+	authContext, creds, err := sig.Authenticate(authenticator, lookupCredentials, s.bareDomain)
 	if err != nil {
-		if !errors.Is(err, db.ErrNotFound) {
-			o.Log().WithError(err).WithField("key", authContext.GetAccessKeyID()).Warn("error getting access key")
-			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
-		} else {
-			o.Log().WithError(err).WithField("key", authContext.GetAccessKeyID()).Warn("could not find access key")
-			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrAccessDenied))
+		// the reason tells malformed requests, clock skew and signature mismatches apart, the signature itself is not logged
+		reason, _ := sig.S3ErrorCode(err)
+		fields := logging.Fields{
+			"authenticator": authenticator,
+			"reason":        reason,
 		}
+		if authContext != nil {
+			fields["key"] = authContext.GetAccessKeyID()
+			fields["signature_version"] = authContext.GetSignatureVersion()
+			fields["signed_headers"] = authContext.GetSignedHeaders()
+		}
+		o.Log().WithError(err).WithFields(fields).Warn("failed to authenticate request")
+		o.EncodeError(sig.APIErrorCode(err).ToAPIErr())
 		return nil
 	}
-
-	err = authenticator.Verify(creds, s.bareDomain)
-	if err != nil {
-		o.Log().WithError(err).WithFields(logging.Fields{
-			"key":           authContext.GetAccessKeyID(),
-			"authenticator": authenticator,
-		}).Warn("error verifying credentials for key")
-		o.EncodeError(getAPIErrOrDefault(err, gatewayerrors.ErrAccessDenied))
+	if sig.IsAnonymous(authContext) {
+		// there are no public resources, every request must be signed
+		o.Log().Warn("anonymous request denied")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrAccessDenied))
+		return nil
+	}
+	// requests signed for other services must not be replayed against the gateway
+	if service := authContext.GetService(); service != "" && service != s3Service {
+		o.Log().WithField("service", service).Warn("request signed for a different service")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrWrongService))
 		return nil
 	}
 
@@ -232,7 +266,7 @@ func OperationHandler(sc *ServerContext, handler operations.AuthenticatedOperati
 			o.EncodeError(gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, perms)
+		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, "", perms)
 		if authOp == nil {
 			return
 		}
@@ -250,7 +284,7 @@ func RepoOperationHandler(sc *ServerContext, repoID string, handler operations.R
 			o.EncodeError(gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, perms)
+		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, repoID, perms)
 		if authOp == nil {
 			return
 		}
@@ -287,7 +321,7 @@ func PathOperationHandler(sc *ServerContext, repoID, refID, path string, handler
 			o.EncodeError(gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, perms)
+		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, repoID, perms)
 		if authOp == nil {
 			return
 		}
@@ -402,14 +436,10 @@ func (h *handler) servePathBased(r *http.Request) http.Handler {
 
 func (h *handler) serveVirtualHost(r *http.Request) http.Handler {
 	// is it a virtual host?
-	host := httputil.HostOnly(r.Host)
-	ourHost := httputil.HostOnly(h.BareDomain)
-	if !strings.HasSuffix(host, ourHost) {
+	repository, ok := sig.VirtualHostBucket(r.Host, h.BareDomain)
+	if !ok {
 		return nil
 	}
-
-	// remove bare domain suffix
-	repository := strings.TrimSuffix(host, "."+ourHost)
 	if !catalog.IsValidRepositoryName(repository) {
 		return h.NotFoundHandler
 	}
@@ -7,7 +7,9 @@ import (
 )
 
 var (
-	ErrBadRange = fmt.Errorf("unsatisfiable range")
+	ErrBadRange       = fmt.Errorf("unsatisfiable range")
+	ErrMalformedRange = fmt.Errorf("malformed range")
+	ErrMultipleRanges = fmt.Errorf("multiple ranges")
 )
 
 // Range represents an RFC 2616 HTTP Range
@@ -20,39 +22,54 @@ func (r Range) String() string {
 	return fmt.Sprintf("start=%d, end=%d (total=%d)", r.StartOffset, r.EndOffset, r.EndOffset-r.StartOffset+1)
 }
 
-// ParseRange parses an HTTP RFC 2616 Range header value and returns an Range object for the given object length
+// ParseRange parses an HTTP RFC 2616 Range header value and returns an Range object for the given object length.
+// A spec that cannot be parsed returns ErrMalformedRange and a spec with more than one range returns
+// ErrMultipleRanges, S3 ignores the header in both cases and returns the whole object.  A valid spec that selects
+// no byte of the object returns ErrBadRange, which S3 answers with InvalidRange.
 func ParseRange(spec string, length int64) (Range, error) {
-	// Amazon S3 doesn't support retrieving multiple ranges of data per GET request.
 	var r Range
 	if !strings.HasPrefix(spec, "bytes=") {
-		return r, ErrBadRange
+		return r, ErrMalformedRange
 	}
 	spec = strings.TrimPrefix(spec, "bytes=")
+	// Amazon S3 doesn't support retrieving multiple ranges of data per GET request.
+	if strings.Contains(spec, ",") {
+		return r, ErrMultipleRanges
+	}
 	parts := strings.Split(spec, "-")
 	const rangeParts = 2
 	if len(parts) != rangeParts {
-		return r, ErrBadRange
+		return r, ErrMalformedRange
 	}
 
 	fromString := parts[0]
 	toString := parts[1]
 	if len(fromString) == 0 && len(toString) == 0 {
-		return r, ErrBadRange
+		return r, ErrMalformedRange
 	}
-	// negative only
+	// negative only - the last bytes of the object, all of it if the suffix is longer
 	if len(fromString) == 0 {
-		endOffset, err := strconv.ParseInt(toString, 10, 64)
-		if err != nil || endOffset > length {
+		suffixLength, err := strconv.ParseInt(toString, 10, 64)
+		if err != nil || suffixLength < 0 {
+			return r, ErrMalformedRange
+		}
+		if suffixLength == 0 || length == 0 {
 			return r, ErrBadRange
 		}
-		r.StartOffset = length - endOffset
+		if suffixLength > length {
+			suffixLength = length
+		}
+		r.StartOffset = length - suffixLength
 		r.EndOffset = length - 1
 		return r, nil
 	}
+	beginOffset, err := strconv.ParseInt(fromString, 10, 64)
+	if err != nil || beginOffset < 0 {
+		return r, ErrMalformedRange
+	}
 	// positive only
 	if len(toString) == 0 {
-		beginOffset, err := strconv.ParseInt(fromString, 10, 64)
-		if err != nil || beginOffset > length-1 {
+		if beginOffset > length-1 {
 			return r, ErrBadRange
 		}
 		r.StartOffset = beginOffset
@@ -60,21 +77,17 @@ func ParseRange(spec string, length int64) (Range, error) {
 		return r, nil
 	}
 	// both set
-	beginOffset, err := strconv.ParseInt(fromString, 10, 64)
-	if err != nil {
-		return r, ErrBadRange
-	}
 	endOffset, err := strconv.ParseInt(toString, 10, 64)
-	if err != nil {
+	if err != nil || endOffset < beginOffset {
+		return r, ErrMalformedRange
+	}
+	if beginOffset > length-1 {
 		return r, ErrBadRange
 	}
 	// if endOffset exceeds length return length : this is how it works in s3 (presto for example uses range with a huge endOffset regardless to the file size)
 	if endOffset > length-1 {
 		endOffset = length - 1
 	}
-	if beginOffset > length-1 || endOffset > length-1 {
-		return r, ErrBadRange
-	}
 	r.StartOffset = beginOffset
 	r.EndOffset = endOffset
 	return r, nil
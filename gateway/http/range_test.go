@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestParseRange(t *testing.T) {
 		{"bytes=0-20", 10, false, 0, 9},
 		{"bytes=-20", 50, false, 30, 49},
 		{"bytes=20-", 50, false, 20, 49},
-		{"bytes=-20", 10, true, 0, 0},
+		{"bytes=-20", 10, false, 0, 9},
 		{"bytes=0-20", 20, false, 0, 19},
 		{"bytes=0-19", 20, false, 0, 19},
 		{"bytes=-0-19", 20, true, 0, 0},
@@ -29,6 +30,13 @@ func TestParseRange(t *testing.T) {
 		{"bytes=0-foo", 20, true, 0, 0},
 		{"bytes=foo-19", 20, true, 0, 0},
 		{"bytes=21-", 20, true, 0, 0},
+		{"bytes=19-", 20, false, 19, 19},
+		{"bytes=5-5", 20, false, 5, 5},
+		{"bytes=-1", 20, false, 19, 19},
+		{"bytes=-0", 20, true, 0, 0},
+		{"bytes=20-30", 20, true, 0, 0},
+		{"bytes=5-3", 20, true, 0, 0},
+		{"bytes=0-1,5-6", 20, true, 0, 0},
 	}
 
 	for _, c := range cases {
@@ -49,3 +57,30 @@ func TestParseRange(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRange_Errors(t *testing.T) {
+	cases := []struct {
+		Spec          string
+		Length        int64
+		ExpectedError error
+	}{
+		{"bytes=0-", 0, http.ErrBadRange},
+		{"bytes=-5", 0, http.ErrBadRange},
+		{"bytes=-0", 20, http.ErrBadRange},
+		{"bytes=20-", 20, http.ErrBadRange},
+		{"bytes=20-30", 20, http.ErrBadRange},
+		{"bytes=5-3", 20, http.ErrMalformedRange},
+		{"bytes=", 20, http.ErrMalformedRange},
+		{"items=0-5", 20, http.ErrMalformedRange},
+		{"bytes=0-1,5-6", 20, http.ErrMultipleRanges},
+		{"bytes=-5,-1", 20, http.ErrMultipleRanges},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s_length_%d", c.Spec, c.Length), func(t *testing.T) {
+			_, err := http.ParseRange(c.Spec, c.Length)
+			if !errors.Is(err, c.ExpectedError) {
+				t.Fatalf("got err=%v, expected %v", err, c.ExpectedError)
+			}
+		})
+	}
+}
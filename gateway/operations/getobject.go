@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/treeverse/lakefs/block"
@@ -12,8 +13,10 @@ import (
 	"github.com/treeverse/lakefs/db"
 	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
 	ghttp "github.com/treeverse/lakefs/gateway/http"
+	"github.com/treeverse/lakefs/gateway/path"
 	"github.com/treeverse/lakefs/gateway/serde"
 	"github.com/treeverse/lakefs/httputil"
+	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/permissions"
 )
 
@@ -28,9 +31,46 @@ func (controller *GetObject) RequiredPermissions(_ *http.Request, repoID, _, pat
 	}, nil
 }
 
+// HandleListParts lists the parts uploaded so far to a multipart upload, all in a single response
+func (controller *GetObject) HandleListParts(o *PathOperation) {
+	o.Incr("list_mpu_parts")
+	uploadID := o.Request.URL.Query().Get(QueryParamUploadID)
+	o.AddLogFields(logging.Fields{"upload_id": uploadID})
+	parts, err := o.Cataloger.ListMultipartUploadParts(o.Context(), o.Repository.Name, uploadID)
+	if errors.Is(err, catalog.ErrMultipartUploadNotFound) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchUpload))
+		return
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("could not list multipart upload parts")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	result := serde.ListPartsResult{
+		Bucket:   o.Repository.Name,
+		Key:      path.WithRef(o.Path, o.Reference),
+		UploadID: uploadID,
+		Part:     make([]serde.ListPartsPart, len(parts)),
+	}
+	for i, part := range parts {
+		result.Part[i] = serde.ListPartsPart{
+			PartNumber:   part.PartNumber,
+			LastModified: serde.Timestamp(part.CreationDate),
+			ETag:         httputil.ETag(strings.Trim(part.ETag, `"`)),
+			Size:         part.Size,
+		}
+	}
+	o.EncodeResponse(&result, http.StatusOK)
+}
+
 func (controller *GetObject) Handle(o *PathOperation) {
-	o.Incr("get_object")
 	query := o.Request.URL.Query()
+	if _, exists := query[QueryParamUploadID]; exists {
+		controller.HandleListParts(o)
+		return
+	}
+
+	o.Incr("get_object")
 	if _, exists := query["versioning"]; exists {
 		o.EncodeResponse(serde.VersioningConfiguration{}, http.StatusOK)
 		return
@@ -64,7 +104,9 @@ func (controller *GetObject) Handle(o *PathOperation) {
 
 	o.SetHeader("Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
 	o.SetHeader("ETag", httputil.ETag(entry.Checksum))
+	o.SetHeader("Content-Type", entryContentType(entry))
 	o.SetHeader("Accept-Ranges", "bytes")
+	o.setAmzMetaHeaders(entry.Metadata)
 	// TODO: the rest of https://docs.aws.amazon.com/en_pv/AmazonS3/latest/API/API_GetObject.html
 
 	// range query
@@ -72,15 +114,22 @@ func (controller *GetObject) Handle(o *PathOperation) {
 	var data io.ReadCloser
 	var rng ghttp.Range
 	rng.StartOffset = -1
-	// range query
 	rangeSpec := o.Request.Header.Get("Range")
 	if len(rangeSpec) > 0 {
 		rng, err = ghttp.ParseRange(rangeSpec, entry.Size)
+		if errors.Is(err, ghttp.ErrBadRange) {
+			o.Log().WithError(err).WithField("range", rangeSpec).Debug("unsatisfiable range")
+			o.SetHeader("Content-Range", fmt.Sprintf("bytes */%d", entry.Size))
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidRange))
+			return
+		}
 		if err != nil {
+			// like S3, ignore a malformed or multiple range spec and return the whole object
 			o.Log().WithError(err).WithField("range", rangeSpec).Debug("invalid range spec")
+			rng.StartOffset = -1
 		}
 	}
-	if rangeSpec == "" || err != nil {
+	if rng.StartOffset == -1 {
 		// assemble a response body (range-less query)
 		expected = entry.Size
 		data, err = o.BlockStore.Get(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: entry.PhysicalAddress}, entry.Size)
@@ -98,6 +147,7 @@ func (controller *GetObject) Handle(o *PathOperation) {
 	o.SetHeader("Content-Length", fmt.Sprintf("%d", expected))
 	if rng.StartOffset != -1 {
 		o.SetHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rng.StartOffset, rng.EndOffset, entry.Size))
+		o.ResponseWriter.WriteHeader(http.StatusPartialContent)
 	}
 	_, err = io.Copy(o.ResponseWriter, data)
 	if err != nil {
@@ -40,7 +40,9 @@ func (controller *HeadObject) Handle(o *PathOperation) {
 	o.SetHeader("Accept-Ranges", "bytes")
 	o.SetHeader("Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
 	o.SetHeader("ETag", httputil.ETag(entry.Checksum))
+	o.SetHeader("Content-Type", entryContentType(entry))
 	o.SetHeader("Content-Length", fmt.Sprintf("%d", entry.Size))
+	o.setAmzMetaHeaders(entry.Metadata)
 	if entry.Expired {
 		o.Log().WithError(err).Info("querying expired object")
 		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
@@ -1,20 +1,34 @@
 package operations
 
 import (
+	"encoding/base64"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/logging"
+	"github.com/treeverse/lakefs/upload"
 )
 
-func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress string, size int64) error {
+const amzMetaHeaderPrefix = "x-amz-meta-"
+
+// amzChecksumHeaders are the x-amz-checksum-* headers of the supported additional checksum algorithms
+var amzChecksumHeaders = map[catalog.ChecksumAlgorithm]string{
+	catalog.ChecksumAlgorithmSHA256: "x-amz-checksum-sha256",
+	catalog.ChecksumAlgorithmCRC32C: "x-amz-checksum-crc32c",
+}
+
+func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress string, size int64, checksums catalog.Checksums, metadata catalog.Metadata, contentType string) error {
 	// write metadata
 	writeTime := time.Now()
 	entry := catalog.Entry{
 		Path:            o.Path,
 		PhysicalAddress: physicalAddress,
 		Checksum:        checksum,
-		Metadata:        nil, // TODO: Read whatever metadata came from the request headers/params and add here
+		Metadata:        metadata,
+		Checksums:       checksums,
+		ContentType:     contentType,
 		Size:            size,
 		CreationDate:    writeTime,
 	}
@@ -36,3 +50,68 @@ func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress
 	}).Debug("metadata update complete")
 	return nil
 }
+
+// entryContentType returns the content type of entry, guessed from its path for entries stored without one
+func entryContentType(entry *catalog.Entry) string {
+	if entry.ContentType != "" {
+		return entry.ContentType
+	}
+	return catalog.ContentTypeByPath(entry.Path)
+}
+
+// amzMetaAsMetadata returns the user-defined metadata (x-amz-meta-* headers) of a request, keyed by the lowercase
+// name following the prefix
+func amzMetaAsMetadata(header http.Header) catalog.Metadata {
+	var metadata catalog.Metadata
+	for name, values := range header {
+		name = strings.ToLower(name)
+		if !strings.HasPrefix(name, amzMetaHeaderPrefix) || len(values) == 0 {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(catalog.Metadata)
+		}
+		metadata[strings.TrimPrefix(name, amzMetaHeaderPrefix)] = strings.Join(values, ",")
+	}
+	return metadata
+}
+
+// setAmzMetaHeaders returns the user-defined metadata of an entry as x-amz-meta-* response headers
+func (o *PathOperation) setAmzMetaHeaders(metadata catalog.Metadata) {
+	for k, v := range metadata {
+		o.SetHeader(amzMetaHeaderPrefix+k, v)
+	}
+}
+
+// blobChecksums returns the additional checksums of an uploaded blob
+func blobChecksums(blob *upload.Blob) catalog.Checksums {
+	return catalog.Checksums{
+		catalog.ChecksumAlgorithmSHA256: base64.StdEncoding.EncodeToString(blob.Sha256),
+		catalog.ChecksumAlgorithmCRC32C: base64.StdEncoding.EncodeToString(blob.CRC32C),
+	}
+}
+
+// amzChecksumsFromHeader returns the checksums a client sent in x-amz-checksum-* headers
+func amzChecksumsFromHeader(header http.Header) catalog.Checksums {
+	var checksums catalog.Checksums
+	for algorithm, name := range amzChecksumHeaders {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if checksums == nil {
+			checksums = make(catalog.Checksums)
+		}
+		checksums[algorithm] = value
+	}
+	return checksums
+}
+
+// setAmzChecksumHeaders returns checksums as x-amz-checksum-* response headers, only for the algorithms in requested
+func (o *PathOperation) setAmzChecksumHeaders(checksums, requested catalog.Checksums) {
+	for algorithm := range requested {
+		if checksum, ok := checksums[algorithm]; ok {
+			o.SetHeader(amzChecksumHeaders[algorithm], checksum)
+		}
+	}
+}
@@ -3,14 +3,16 @@ package operations
 import (
 	"encoding/hex"
 	"encoding/xml"
+	stderrors "errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/google/uuid"
 	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/gateway/errors"
 	"github.com/treeverse/lakefs/gateway/path"
 	"github.com/treeverse/lakefs/gateway/serde"
@@ -47,7 +49,12 @@ func (controller *PostObject) HandleCreateMultipartUpload(o *PathOperation) {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
-	err = o.Cataloger.CreateMultipartUpload(o.Context(), o.Repository.Name, uploadID, o.Path, objName, time.Now())
+	// S3 takes the object metadata and content type on create, complete requests carry neither
+	err = o.Cataloger.CreateMultipartUpload(o.Context(), o.Repository.Name, uploadID, o.Path, objName, time.Now(),
+		catalog.CreateMultipartUploadParams{
+			Metadata:    amzMetaAsMetadata(o.Request.Header),
+			ContentType: o.Request.Header.Get("Content-Type"),
+		})
 	if err != nil {
 		o.Log().WithError(err).Error("could not write multipart upload to DB")
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
@@ -60,13 +67,7 @@ func (controller *PostObject) HandleCreateMultipartUpload(o *PathOperation) {
 	}, http.StatusOK)
 }
 
-func trimQuotes(s string) string {
-	return strings.Trim(s, "\"")
-}
-
 func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
-	var etag *string
-	var size int64
 	o.Incr("complete_mpu")
 	uploadID := o.Request.URL.Query().Get(CompleteMultipartUploadQueryParam)
 	o.AddLogFields(logging.Fields{"upload_id": uploadID})
@@ -91,22 +92,28 @@ func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
-	etag, size, err = o.BlockStore.CompleteMultiPartUpload(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: objName}, uploadID, &MultipartList)
+	_, _, err = o.BlockStore.CompleteMultiPartUpload(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: objName}, uploadID, &MultipartList)
 	if err != nil {
 		o.Log().WithError(err).Error("could not complete multipart upload")
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
-	ch := trimQuotes(*etag)
-	checksum := strings.Split(ch, "-")[0]
-	err = o.finishUpload(o.Repository.StorageNamespace, checksum, objName, size)
-	if err != nil {
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+	// the catalog matches the listed parts to the uploaded parts and creates the entry, with a checksum of the
+	// ETag S3 computes for multipart objects so it matches what clients compute
+	parts := make([]catalog.MultipartUploadPart, len(MultipartList.Part))
+	for i, part := range MultipartList.Part {
+		parts[i] = catalog.MultipartUploadPart{PartNumber: int(aws.Int64Value(part.PartNumber)), ETag: aws.StringValue(part.ETag)}
+	}
+	entry, err := o.Cataloger.CompleteMultipartUpload(o.Context(), o.Repository.Name, o.Reference, uploadID, parts)
+	if stderrors.Is(err, catalog.ErrInvalidMultipartUploadPart) {
+		o.Log().WithError(err).Warn("multipart upload parts do not match uploaded parts")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidPart))
 		return
 	}
-	err = o.Cataloger.DeleteMultipartUpload(o.Context(), o.Repository.Name, uploadID)
 	if err != nil {
-		o.Log().WithError(err).Warn("could not delete multipart record")
+		o.Log().WithError(err).Error("could not write multipart upload entry to DB")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		return
 	}
 
 	scheme := httputil.RequestScheme(o.Request)
@@ -115,7 +122,7 @@ func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
 		Location: location,
 		Bucket:   o.Repository.Name,
 		Key:      path.WithRef(o.Path, o.Reference),
-		ETag:     *etag,
+		ETag:     httputil.ETag(entry.Checksum),
 	}, http.StatusOK)
 }
 
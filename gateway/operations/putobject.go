@@ -1,7 +1,6 @@
 package operations
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -77,7 +76,7 @@ func (controller *PutObject) HandleCopy(o *PathOperation, copySource string) {
 
 	o.EncodeResponse(&serde.CopyObjectResult{
 		LastModified: serde.Timestamp(ent.CreationDate),
-		ETag:         fmt.Sprintf("\"%s\"", ent.Checksum),
+		ETag:         httputil.ETag(ent.Checksum),
 	}, http.StatusOK)
 }
 
@@ -107,13 +106,25 @@ func (controller *PutObject) HandleUploadPart(o *PathOperation) {
 		return
 	}
 	byteSize := o.Request.ContentLength
+	body := block.NewHashingReader(o.Request.Body)
 	etag, err := o.BlockStore.UploadPart(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: multiPart.PhysicalAddress},
-		byteSize, o.Request.Body, uploadID, partNumber)
+		byteSize, body, uploadID, partNumber)
 	if err != nil {
 		o.Log().WithError(err).Error("part " + partNumberStr + " upload failed")
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
+	err = o.Cataloger.PutMultipartUploadPart(o.Context(), o.Repository.Name, uploadID, catalog.MultipartUploadPart{
+		PartNumber:      int(partNumber),
+		ETag:            etag,
+		Size:            body.CopiedSize,
+		PhysicalAddress: multiPart.PhysicalAddress,
+	})
+	if err != nil {
+		o.Log().WithError(err).Error("could not write multipart upload part to DB")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		return
+	}
 	o.SetHeader("ETag", etag)
 	o.ResponseWriter.WriteHeader(http.StatusOK)
 }
@@ -166,12 +177,27 @@ func (controller *PutObject) Handle(o *PathOperation) {
 		return
 	}
 
+	// verify checksums sent by the client
+	checksums := blobChecksums(blob)
+	requestedChecksums := amzChecksumsFromHeader(o.Request.Header)
+	if err := checksums.Verify(requestedChecksums); err != nil {
+		o.Log().WithError(err).Warn("checksum of request body does not match")
+		// no entry refers to the stored data
+		obj := block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: blob.PhysicalAddress}
+		if err := o.BlockStore.Remove(obj); err != nil {
+			o.Log().WithError(err).WithField("physical_address", blob.PhysicalAddress).Warn("could not remove data of bad checksum upload")
+		}
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrBadChecksum))
+		return
+	}
+
 	// write metadata
-	err = o.finishUpload(o.Repository.StorageNamespace, blob.Checksum, blob.PhysicalAddress, blob.Size)
+	err = o.finishUpload(o.Repository.StorageNamespace, blob.Checksum, blob.PhysicalAddress, blob.Size, checksums, amzMetaAsMetadata(o.Request.Header), o.Request.Header.Get("Content-Type"))
 	if err != nil {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
 	o.SetHeader("ETag", httputil.ETag(blob.Checksum))
+	o.setAmzChecksumHeaders(checksums, requestedChecksums)
 	o.ResponseWriter.WriteHeader(http.StatusOK)
 }
@@ -146,6 +146,22 @@ type CompleteMultipartUploadResult struct {
 	ETag     string `xml:"ETag"`
 }
 
+type ListPartsPart struct {
+	PartNumber   int    `xml:"PartNumber"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+}
+
+type ListPartsResult struct {
+	XMLName     xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListPartsResult"`
+	Bucket      string          `xml:"Bucket"`
+	Key         string          `xml:"Key"`
+	UploadID    string          `xml:"UploadId"`
+	IsTruncated bool            `xml:"IsTruncated"`
+	Part        []ListPartsPart `xml:"Part"`
+}
+
 type VersioningConfiguration struct {
 	Enabled bool `xml:"Enabled,omitempty"`
 }
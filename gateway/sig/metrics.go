@@ -0,0 +1,43 @@
+package sig
+
+import (
+	"errors"
+
+	gwErrors "github.com/treeverse/lakefs/gateway/errors"
+)
+
+// Signature verification results reported to the MetricsRecorder
+const (
+	VerificationResultSuccess      = "success"
+	VerificationResultBadSignature = "bad-signature"
+	VerificationResultMalformed    = "malformed"
+	VerificationResultSkew         = "skew"
+)
+
+// MetricsRecorder is notified of the result of every signature verification
+type MetricsRecorder interface {
+	RecordVerification(result string)
+}
+
+// recordVerification notifies recorder of the result of a signature verification that returned err, if recorder is set
+func recordVerification(recorder MetricsRecorder, err error) {
+	if recorder != nil {
+		recorder.RecordVerification(verificationResult(err))
+	}
+}
+
+// verificationResult returns the result reported for the error returned by a signature verification.
+// Requests signed outside the allowed time window are reported as skew, requests that do not match the credentials
+// as bad-signature and requests that could not be verified at all as malformed.
+func verificationResult(err error) string {
+	switch {
+	case err == nil:
+		return VerificationResultSuccess
+	case errors.Is(err, gwErrors.ErrSignatureDoesNotMatch), errors.Is(err, gwErrors.ErrInvalidToken):
+		return VerificationResultBadSignature
+	case errors.Is(err, gwErrors.ErrRequestTimeTooSkewed), errors.Is(err, gwErrors.ErrExpiredPresignRequest):
+		return VerificationResultSkew
+	default:
+		return VerificationResultMalformed
+	}
+}
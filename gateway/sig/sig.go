@@ -2,81 +2,121 @@ package sig
 
 import (
 	"crypto/hmac"
-	"encoding/hex"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
-	"unicode/utf8"
 
 	gwErrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/httputil"
 
 	"github.com/treeverse/lakefs/auth/model"
 
 	"errors"
 )
 
+// DefaultAccessKeyIDPattern matches access key ids as defined by AWS IAM, which includes the keys issued by lakeFS
+const DefaultAccessKeyIDPattern = `\w{16,128}`
+
 var (
-	ErrHeaderMalformed = errors.New("header malformed")
+	ErrHeaderMalformed   = errors.New("header malformed")
+	ErrAccessKeyNotFound = errors.New("access key not found")
+	ErrWrongRegion       = errors.New("wrong region")
 
 	// if object matches reserved string, no need to encode them
 	reservedObjectNames = regexp.MustCompile("^[a-zA-Z0-9-_.~/]+$")
 )
 
-// taken from https://github.com/minio/minio-go/blob/master/pkg/s3utils/utils.go
-/*
- * MinIO Go Library for Amazon S3 Compatible Cloud Storage
- * Copyright 2015-2017 MinIO, Inc.
- *
- * Licensed under the Apache License, Version 2.0 (the "License");
- * you may not use this file except in compliance with the License.
- * You may obtain a copy of the License at
- *
- *     http://www.apache.org/licenses/LICENSE-2.0
- *
- * Unless required by applicable law or agreed to in writing, software
- * distributed under the License is distributed on an "AS IS" BASIS,
- * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
- * See the License for the specific language governing permissions and
- * limitations under the License.
- */
-// EncodePath encode the strings from UTF-8 byte representations to HTML hex escape sequences
-// This is necessary since regular url.Parse() and url.Encode() functions do not support UTF-8
-// non english characters cannot be parsed due to the nature in which url.Encode() is written
-// This function on the other hand is a direct replacement for url.Encode() technique to support
-// pretty much every UTF-8 character.
+// EncodePath encodes a request path the way AWS builds the canonical URI of S3 requests: every byte other than the
+// unreserved characters and the '/' separators is percent-encoded, once. Bytes are encoded as they are, so keys that
+// are not valid UTF-8 match the encoding the client signed.
 func EncodePath(pathName string) string {
 	if reservedObjectNames.MatchString(pathName) {
 		return pathName
 	}
-	var encodedPathname string
-	for _, s := range pathName {
-		if 'A' <= s && s <= 'Z' || 'a' <= s && s <= 'z' || '0' <= s && s <= '9' { // §2.3 Unreserved characters (mark)
-			encodedPathname += string(s)
-			continue
-		}
-		switch s {
-		case '-', '_', '.', '~', '/': // §2.3 Unreserved characters (mark)
-			encodedPathname += string(s)
+	const upperHex = "0123456789ABCDEF"
+	var buf strings.Builder
+	buf.Grow(len(pathName) * 3)
+	for i := 0; i < len(pathName); i++ {
+		c := pathName[i]
+		if isUnreservedPathByte(c) {
+			buf.WriteByte(c)
 			continue
-		default:
-			runeLen := utf8.RuneLen(s)
-			if runeLen < 0 {
-				// if utf8 cannot convert return the same string as is
-				return pathName
-			}
-			u := make([]byte, runeLen)
-			utf8.EncodeRune(u, s)
-			for _, r := range u {
-				h := hex.EncodeToString([]byte{r})
-				encodedPathname += "%" + strings.ToUpper(h)
-			}
 		}
+		buf.WriteByte('%')
+		buf.WriteByte(upperHex[c>>4])
+		buf.WriteByte(upperHex[c&0x0f])
+	}
+	return buf.String()
+}
+
+// isUnreservedPathByte returns true for the characters that are not encoded in a canonical URI (RFC 3986 §2.3)
+func isUnreservedPathByte(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '~' || c == '/'
+}
+
+// AccessKeyIDPattern parses signatures whose access key ids match a regular expression
+type AccessKeyIDPattern struct {
+	v2AuthHeader      *regexp.Regexp
+	v4AuthHeader      *regexp.Regexp
+	v4CredentialScope *regexp.Regexp
+	// v4AuthHeaderFastPath is set for the default pattern, which splitV4AuthHeader checks
+	v4AuthHeaderFastPath bool
+}
+
+// NewAccessKeyIDPattern returns an AccessKeyIDPattern matching access key ids with the regular expression pattern
+func NewAccessKeyIDPattern(pattern string) (*AccessKeyIDPattern, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, fmt.Errorf("access key id pattern: %w", err)
+	}
+	return &AccessKeyIDPattern{
+		v2AuthHeader:         newV2AuthHeaderRegexp(pattern),
+		v4AuthHeader:         newV4AuthHeaderRegexp(pattern),
+		v4CredentialScope:    newV4CredentialScopeRegexp(pattern),
+		v4AuthHeaderFastPath: pattern == DefaultAccessKeyIDPattern,
+	}, nil
+}
+
+var defaultAccessKeyIDPattern = &AccessKeyIDPattern{
+	v2AuthHeader:         V2AuthHeaderRegexp,
+	v4AuthHeader:         V4AuthHeaderRegexp,
+	v4CredentialScope:    V4CredentialScopeRegexp,
+	v4AuthHeaderFastPath: true,
+}
+
+// accessKeyIDPatternOrDefault returns p, or the pattern of DefaultAccessKeyIDPattern if p is nil
+func accessKeyIDPatternOrDefault(p *AccessKeyIDPattern) *AccessKeyIDPattern {
+	if p == nil {
+		return defaultAccessKeyIDPattern
 	}
-	return encodedPathname
+	return p
+}
+
+// Config configures the authenticators returned by NewAuthenticator
+type Config struct {
+	// AccessKeyIDPattern matches the access key ids of signatures, nil matches DefaultAccessKeyIDPattern
+	AccessKeyIDPattern *AccessKeyIDPattern
+	// ForwardedHostHeaders are the headers sigv4 requests are verified with the host of, see
+	// V4Authenticator.ForwardedHostHeaders
+	ForwardedHostHeaders []string
+	// AllowedRegions returns the regions requests may be signed for, nil accepts every region
+	AllowedRegions RegionsLookup
+	// MetricsRecorder is notified of every signature verification, nil disables recording
+	MetricsRecorder MetricsRecorder
 }
 
 type SigContext interface {
 	GetAccessKeyID() string
+	// GetRegion returns the region the request was signed for, empty when the signature is not scoped to a region
+	GetRegion() string
+	// GetService returns the service the request was signed for, empty when the signature is not scoped to a service
+	GetService() string
+	// GetSignedHeaders returns the names of the request headers covered by the signature, nil when the signature
+	// does not list them
+	GetSignedHeaders() []string
+	// GetSignatureVersion returns the signing scheme used to sign the request, empty for unsigned requests
+	GetSignatureVersion() string
 }
 
 type SigAuthenticator interface {
@@ -84,6 +124,30 @@ type SigAuthenticator interface {
 	Verify(*model.Credential, string) error
 }
 
+// CredentialsLookup returns the credentials of an access key id, or ErrAccessKeyNotFound if it is unknown
+type CredentialsLookup func(accessKeyID string) (*model.Credential, error)
+
+// Authenticate parses the signature of the request, looks up the credentials of the access key that signed it
+// and verifies the signature using them. The signature context is returned whenever parsing succeeded.
+// Anonymous requests are returned with no credentials.
+func Authenticate(authenticator SigAuthenticator, lookup CredentialsLookup, bareDomain string) (SigContext, *model.Credential, error) {
+	sigCtx, err := authenticator.Parse()
+	if err != nil {
+		return nil, nil, err
+	}
+	if IsAnonymous(sigCtx) {
+		return sigCtx, nil, nil
+	}
+	creds, err := lookup(sigCtx.GetAccessKeyID())
+	if err != nil {
+		return sigCtx, nil, err
+	}
+	if err := authenticator.Verify(creds, bareDomain); err != nil {
+		return sigCtx, nil, err
+	}
+	return sigCtx, creds, nil
+}
+
 type chainedAuthenticator struct {
 	methods []SigAuthenticator
 	chosen  SigAuthenticator
@@ -93,6 +157,103 @@ func ChainedAuthenticator(methods ...SigAuthenticator) SigAuthenticator {
 	return &chainedAuthenticator{methods, nil}
 }
 
+// NewAuthenticator returns the authenticator matching the signature version used to sign the request, configured
+// by cfg.  Requests that carry no recognizable signature are handed to a chain of all supported versions.
+func NewAuthenticator(r *http.Request, cfg Config) SigAuthenticator {
+	headerValue := strings.TrimSpace(r.Header.Get(v4authHeaderName))
+	switch {
+	case strings.HasPrefix(headerValue, v4authHeaderPrefix):
+		return cfg.newV4Authenticator(r)
+	case strings.HasPrefix(headerValue, v2authHeaderPrefix):
+		return cfg.newV2SigAuthenticator(r)
+	}
+	query := r.URL.Query()
+	switch {
+	case query.Get(v4QueryAlgorithmName) != "":
+		return cfg.newV4Authenticator(r)
+	case query.Get(v2QueryAccessKeyIDName) != "":
+		return cfg.newV2SigAuthenticator(r)
+	case headerValue == "":
+		return &anonymousAuthenticator{}
+	}
+	return ChainedAuthenticator(cfg.newV4Authenticator(r), cfg.newV2SigAuthenticator(r))
+}
+
+func (cfg Config) newV4Authenticator(r *http.Request) *V4Authenticator {
+	a := NewV4Authenticator(r)
+	a.AccessKeyIDPattern = cfg.AccessKeyIDPattern
+	a.ForwardedHostHeaders = cfg.ForwardedHostHeaders
+	a.AllowedRegions = cfg.AllowedRegions
+	a.MetricsRecorder = cfg.MetricsRecorder
+	return a
+}
+
+func (cfg Config) newV2SigAuthenticator(r *http.Request) *V2SigAuthenticator {
+	a := NewV2SigAuthenticator(r)
+	a.AccessKeyIDPattern = cfg.AccessKeyIDPattern
+	a.AllowedRegions = cfg.AllowedRegions
+	a.MetricsRecorder = cfg.MetricsRecorder
+	return a
+}
+
+// VirtualHostBucket returns the bucket addressed by a virtual-hosted-style request, whose host is the bucket name
+// followed by the bare domain. Ports are ignored and the bare domain is matched case-insensitively.
+// Virtual-hosted-style requests are signed with the host and path they were sent with, like path-style requests, so
+// the bucket is needed only to route the request.
+func VirtualHostBucket(host, bareDomain string) (string, bool) {
+	host = httputil.HostOnly(host)
+	suffix := "." + httputil.HostOnly(bareDomain)
+	if len(host) <= len(suffix) || !strings.EqualFold(host[len(host)-len(suffix):], suffix) {
+		return "", false
+	}
+	return host[:len(host)-len(suffix)], true
+}
+
+// AnonymousSigContext is the signature context of requests that carry no signature at all.
+// It is up to the caller to decide whether such requests are allowed.
+type AnonymousSigContext struct{}
+
+func (AnonymousSigContext) GetAccessKeyID() string {
+	return ""
+}
+
+func (AnonymousSigContext) GetRegion() string {
+	return ""
+}
+
+func (AnonymousSigContext) GetService() string {
+	return ""
+}
+
+func (AnonymousSigContext) GetSignedHeaders() []string {
+	return nil
+}
+
+func (AnonymousSigContext) GetSignatureVersion() string {
+	return ""
+}
+
+// IsAnonymous returns true if the signature context belongs to an unsigned request
+func IsAnonymous(sigCtx SigContext) bool {
+	_, ok := sigCtx.(AnonymousSigContext)
+	return ok
+}
+
+type anonymousAuthenticator struct{}
+
+func (a *anonymousAuthenticator) Parse() (SigContext, error) {
+	return AnonymousSigContext{}, nil
+}
+
+// Verify has nothing to verify for unsigned requests
+func (a *anonymousAuthenticator) Verify(*model.Credential, string) error {
+	return nil
+}
+
+func (a *anonymousAuthenticator) String() string {
+	return "anonymous"
+}
+
 func (c *chainedAuthenticator) Parse() (SigContext, error) {
 	for _, method := range c.methods {
 		ctx, err := method.Parse()
@@ -104,10 +265,46 @@ func (c *chainedAuthenticator) Parse() (SigContext, error) {
 	return nil, gwErrors.ErrMissingFields
 }
 
+// APIErrorCode translates an error returned by an authenticator to the S3 API error code describing it.
+// Errors that carry no specific meaning are reported as access denied.
+func APIErrorCode(err error) gwErrors.APIErrorCode {
+	var apiErrorCode gwErrors.APIErrorCode
+	switch {
+	case err == nil:
+		return gwErrors.ErrNone
+	case errors.As(err, &apiErrorCode):
+		return apiErrorCode
+	case errors.Is(err, ErrHeaderMalformed):
+		return gwErrors.ErrAuthorizationHeaderMalformed
+	case errors.Is(err, ErrWrongRegion):
+		// S3 reports a signature scoped to the wrong region as a malformed authorization header
+		return gwErrors.ErrAuthorizationHeaderMalformed
+	default:
+		return gwErrors.ErrAccessDenied
+	}
+}
+
+// S3ErrorCode returns the S3 error code and HTTP status code to respond with for an error returned by an authenticator
+func S3ErrorCode(err error) (string, int) {
+	apiErr := APIErrorCode(err).ToAPIErr()
+	return apiErr.Code, apiErr.HTTPStatusCode
+}
+
 func Equal(sig1, sig2 []byte) bool {
 	return hmac.Equal(sig1, sig2)
 }
 
+// matchSecretKey returns the first secret key of creds that matches, checking every key in turn.  Credentials may
+// have more than one valid secret key while they are rotated.
+func matchSecretKey(creds *model.Credential, matches func(secretKey string) bool) (string, bool) {
+	for _, secretKey := range creds.GetAccessSecretKeys() {
+		if matches(secretKey) {
+			return secretKey, true
+		}
+	}
+	return "", false
+}
+
 func (c *chainedAuthenticator) Verify(creds *model.Credential, domain string) error {
 	return c.chosen.Verify(creds, domain)
 }
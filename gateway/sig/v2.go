@@ -9,7 +9,9 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/gateway/errors"
@@ -18,11 +20,15 @@ import (
 )
 
 const (
-	v2authHeaderName = "Authorization"
+	v2authHeaderName       = "Authorization"
+	v2authHeaderPrefix     = "AWS "
+	v2QueryAccessKeyIDName = "AWSAccessKeyId"
+	v2QuerySignatureName   = "Signature"
+	v2QueryExpiresName     = "Expires"
 )
 
 var (
-	V2AuthHeaderRegexp = regexp.MustCompile(`AWS (?P<AccessKeyId>[A-Z0-9]{20}):(?P<Signature>[A-Za-z0-9+/=]+)`)
+	V2AuthHeaderRegexp = newV2AuthHeaderRegexp(DefaultAccessKeyIDPattern)
 	// Both "interesting" arrays are sorted. so once we extract relevant items by looping on them = the result is sorted
 	interestingHeaders   = [...]string{"content-md5", "content-type", "date"}
 	interestingResources []string // initialized and sorted by the init function
@@ -65,23 +71,60 @@ func init() {
 	interestingResources = sortedArray
 }
 
+func newV2AuthHeaderRegexp(accessKeyIDPattern string) *regexp.Regexp {
+	return regexp.MustCompile(`AWS (?P<AccessKeyId>` + accessKeyIDPattern + `):(?P<Signature>[A-Za-z0-9+/=]+)`)
+}
+
 type v2Context struct {
 	accessKeyID string
 	signature   []byte
+	// expires is set when the request is authenticated by query parameters, it replaces the date in the signed string
+	expires string
+	// expiresAt is the time the query parameters authenticating the request expire at, parsed from expires
+	expiresAt time.Time
 }
 
 func (a v2Context) GetAccessKeyID() string {
 	return a.accessKeyID
 }
 
+func (a v2Context) GetRegion() string {
+	return ""
+}
+
+func (a v2Context) GetService() string {
+	return ""
+}
+
+// GetSignedHeaders returns nil, sigv2 signs a fixed set of headers and does not list them
+func (a v2Context) GetSignedHeaders() []string {
+	return nil
+}
+
+func (a v2Context) GetSignatureVersion() string {
+	return "sigv2"
+}
+
 type V2SigAuthenticator struct {
 	r   *http.Request
 	ctx v2Context
+	// MaxClockSkew is the time a request authenticated by query parameters is accepted after it expires
+	MaxClockSkew time.Duration
+	// AllowedRegions returns the regions requests to Repository may be signed for.  Sigv2 signatures are not
+	// scoped to a region, so Verify fails with ErrWrongRegion whenever it returns any region.
+	AllowedRegions RegionsLookup
+	// Repository is the repository the request addresses, empty if it does not address one
+	Repository string
+	// AccessKeyIDPattern matches the access key ids of signatures, nil matches DefaultAccessKeyIDPattern
+	AccessKeyIDPattern *AccessKeyIDPattern
+	// MetricsRecorder is notified of the result of Verify, nil disables recording
+	MetricsRecorder MetricsRecorder
 }
 
 func NewV2SigAuthenticator(r *http.Request) *V2SigAuthenticator {
 	return &V2SigAuthenticator{
-		r: r,
+		r:            r,
+		MaxClockSkew: DefaultMaxClockSkew,
 	}
 }
 
@@ -89,13 +132,14 @@ func (a *V2SigAuthenticator) Parse() (SigContext, error) {
 	var ctx v2Context
 	headerValue := a.r.Header.Get(v2authHeaderName)
 	if len(headerValue) > 0 {
-		match := V2AuthHeaderRegexp.FindStringSubmatch(headerValue)
+		authHeaderRegexp := accessKeyIDPatternOrDefault(a.AccessKeyIDPattern).v2AuthHeader
+		match := authHeaderRegexp.FindStringSubmatch(headerValue)
 		if len(match) == 0 {
 			logging.Default().WithField("header", headerValue).Error("log header does not match v2 structure")
 			return ctx, ErrHeaderMalformed
 		}
 		result := make(map[string]string)
-		for i, name := range V2AuthHeaderRegexp.SubexpNames() {
+		for i, name := range authHeaderRegexp.SubexpNames() {
 			if i != 0 && name != "" {
 				result[name] = match[i]
 			}
@@ -108,7 +152,32 @@ func (a *V2SigAuthenticator) Parse() (SigContext, error) {
 			return ctx, ErrHeaderMalformed
 		}
 		ctx.signature = sig
+		a.ctx = ctx
+		return ctx, nil
+	}
+
+	// otherwise, see if we have all the required query parameters
+	query := a.r.URL.Query()
+	accessKeyID := query.Get(v2QueryAccessKeyIDName)
+	signature := query.Get(v2QuerySignatureName)
+	expires := query.Get(v2QueryExpiresName)
+	if len(accessKeyID) == 0 || len(signature) == 0 || len(expires) == 0 {
+		return ctx, errors.ErrMissingFields
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		logging.Default().WithField("signature", signature).Error("query signature isn't proper base64")
+		return ctx, ErrHeaderMalformed
+	}
+	// the expiration time is in seconds since the epoch
+	expiresSeconds, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ctx, errors.ErrMalformedExpires
 	}
+	ctx.accessKeyID = accessKeyID
+	ctx.signature = sig
+	ctx.expires = expires
+	ctx.expiresAt = time.Unix(expiresSeconds, 0)
 	a.ctx = ctx
 	return ctx, nil
 }
@@ -202,15 +271,11 @@ func signCanonicalString(msg string, signature []byte) (digest []byte) {
 }
 
 func buildPath(host string, bareDomain string, path string) string {
-	h := httputil.HostOnly(host)
-	b := httputil.HostOnly(bareDomain)
-	if h == b {
+	if httputil.HostOnly(host) == httputil.HostOnly(bareDomain) {
 		return path
 	}
-	bareSuffix := "." + b
-	if strings.HasSuffix(h, bareSuffix) {
-		prePath := strings.TrimSuffix(h, bareSuffix)
-		return "/" + prePath + path
+	if bucket, ok := VirtualHostBucket(host, bareDomain); ok {
+		return "/" + bucket + path
 	}
 	// bareDomain is not suffix of the path probably a bug
 	logging.Default().
@@ -220,6 +285,15 @@ func buildPath(host string, bareDomain string, path string) string {
 }
 
 func (a *V2SigAuthenticator) Verify(creds *model.Credential, bareDomain string) error {
+	err := verifyRegion(a.AllowedRegions, a.Repository, "")
+	if err == nil {
+		err = a.verify(creds, bareDomain)
+	}
+	recordVerification(a.MetricsRecorder, err)
+	return err
+}
+
+func (a *V2SigAuthenticator) verify(creds *model.Credential, bareDomain string) error {
 	/*
 		s3 sigV2 implementation:
 		the s3 signature  is somewhat different than general aws signature implementation.
@@ -248,11 +322,23 @@ func (a *V2SigAuthenticator) Verify(creds *model.Credential, bareDomain string)
 	patchedPath = strings.ReplaceAll(patchedPath, "*", "%2A")
 	patchedPath = strings.ReplaceAll(patchedPath, "%7E", "~")
 	path := buildPath(a.r.Host, bareDomain, patchedPath)
-	stringToSigh := canonicalString(a.r.Method, a.r.URL.Query(), path, a.r.Header)
-	digest := signCanonicalString(stringToSigh, []byte(creds.AccessSecretKey))
-	if !Equal(digest, a.ctx.signature) {
+	headers := a.r.Header
+	if a.ctx.expires != "" {
+		// query string authentication signs the expiration time in place of the date header
+		headers = headers.Clone()
+		headers.Set("Date", a.ctx.expires)
+	}
+	stringToSigh := canonicalString(a.r.Method, a.r.URL.Query(), path, headers)
+	_, ok := matchSecretKey(creds, func(secretKey string) bool {
+		return Equal(signCanonicalString(stringToSigh, []byte(secretKey)), a.ctx.signature)
+	})
+	if !ok {
 		return errors.ErrSignatureDoesNotMatch
 	}
+	// requests authenticated by query parameters are valid only until they expire
+	if a.ctx.expires != "" && time.Now().After(a.ctx.expiresAt.Add(a.MaxClockSkew)) {
+		return errors.ErrExpiredPresignRequest
+	}
 	return nil
 }
 
@@ -1,12 +1,12 @@
 package sig
 
 import (
-	"bufio"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -17,6 +17,7 @@ import (
 	"unicode"
 
 	"github.com/treeverse/lakefs/auth/model"
+	"github.com/treeverse/lakefs/cache"
 	"github.com/treeverse/lakefs/gateway/errors"
 )
 
@@ -31,13 +32,50 @@ const (
 	v4timeFormat            = "20060102T150405Z"
 	v4shortTimeFormat       = "20060102"
 	v4SignatureHeader       = "X-Amz-Signature"
+	v4QueryAlgorithmName    = "X-Amz-Algorithm"
+	v4QueryDateName         = "X-Amz-Date"
+	v4QueryExpiresName      = "X-Amz-Expires"
+	v4SecurityTokenName     = "X-Amz-Security-Token"
+
+	// v4MaxExpires is the longest validity period S3 allows for a presigned URL
+	v4MaxExpires = 7 * 24 * time.Hour
+
+	// DefaultMaxClockSkew is the time difference allowed between the client and the server clocks
+	DefaultMaxClockSkew = 15 * time.Minute
 )
 
+const (
+	signingKeyCacheSize   = 1024
+	signingKeyCacheExpiry = time.Hour
+	signingKeyCacheJitter = time.Minute
+)
+
+var signingKeyCache = cache.NewCache(signingKeyCacheSize, signingKeyCacheExpiry, cache.NewJitterFn(signingKeyCacheJitter))
+
+// RegionsLookup returns the regions requests to repository may be signed for, repository is empty for requests that
+// do not address a repository. Requests signed for any region are accepted when it returns no regions.
+type RegionsLookup func(repository string) []string
+
+// AllowRegions returns a RegionsLookup that accepts the same regions for every repository
+func AllowRegions(regions ...string) RegionsLookup {
+	return func(string) []string {
+		return regions
+	}
+}
+
 var (
-	V4AuthHeaderRegexp      = regexp.MustCompile(`AWS4-HMAC-SHA256 Credential=(?P<AccessKeyId>[A-Z0-9]{20})/(?P<Date>\d{8})/(?P<Region>[\w\-]+)/(?P<Service>[\w\-]+)/aws4_request,\s*SignedHeaders=(?P<SignatureHeaders>[\w\-\;]+),\s*Signature=(?P<Signature>[abcdef0123456789]{64})`)
-	V4CredentialScopeRegexp = regexp.MustCompile(`(?P<AccessKeyId>[A-Z0-9]{20})/(?P<Date>\d{8})/(?P<Region>[\w\-]+)/(?P<Service>[\w\-]+)/aws4_request`)
+	V4AuthHeaderRegexp      = newV4AuthHeaderRegexp(DefaultAccessKeyIDPattern)
+	V4CredentialScopeRegexp = newV4CredentialScopeRegexp(DefaultAccessKeyIDPattern)
 )
 
+func newV4AuthHeaderRegexp(accessKeyIDPattern string) *regexp.Regexp {
+	return regexp.MustCompile(`^\s*AWS4-HMAC-SHA256\s+Credential\s*=\s*(?P<AccessKeyId>` + accessKeyIDPattern + `)/(?P<Date>\d{8})/(?P<Region>[\w\-]+)/(?P<Service>[\w\-]+)/aws4_request\s*,\s*SignedHeaders\s*=\s*(?P<SignatureHeaders>[\w\-\;]+)\s*,\s*Signature\s*=\s*(?P<Signature>[0-9a-fA-F]{64})\s*$`)
+}
+
+func newV4CredentialScopeRegexp(accessKeyIDPattern string) *regexp.Regexp {
+	return regexp.MustCompile(`(?P<AccessKeyId>` + accessKeyIDPattern + `)/(?P<Date>\d{8})/(?P<Region>[\w\-]+)/(?P<Service>[\w\-]+)/aws4_request`)
+}
+
 type V4Auth struct {
 	AccessKeyID         string
 	Date                string
@@ -52,44 +90,57 @@ func (a V4Auth) GetAccessKeyID() string {
 	return a.AccessKeyID
 }
 
+func (a V4Auth) GetRegion() string {
+	return a.Region
+}
+
+func (a V4Auth) GetService() string {
+	return a.Service
+}
+
+func (a V4Auth) GetSignedHeaders() []string {
+	return a.SignedHeaders
+}
+
+func (a V4Auth) GetSignatureVersion() string {
+	return "sigv4"
+}
+
 func splitHeaders(headers string) []string {
 	headerValues := strings.Split(headers, ";")
 	sort.Strings(headerValues)
 	return headerValues
 }
 
+// ParseV4AuthContext parses the sigv4 signature of r, with access key ids matching DefaultAccessKeyIDPattern
 func ParseV4AuthContext(r *http.Request) (V4Auth, error) {
+	return parseV4AuthContext(r, defaultAccessKeyIDPattern)
+}
+
+func parseV4AuthContext(r *http.Request, pattern *AccessKeyIDPattern) (V4Auth, error) {
 	var ctx V4Auth
 
-	// start by trying to extract the data from the Authorization header
+	// start by trying to extract the data from the Authorization header, intermediaries may add unrelated
+	// Authorization headers so it is used only when it carries a v4 signature
 	headerValue := r.Header.Get(v4authHeaderName)
-	if len(headerValue) > 0 {
-		match := V4AuthHeaderRegexp.FindStringSubmatch(headerValue)
-		if len(match) == 0 {
-			return ctx, ErrHeaderMalformed
+	if strings.HasPrefix(strings.TrimSpace(headerValue), v4authHeaderPrefix) {
+		var ok bool
+		if pattern.v4AuthHeaderFastPath {
+			ctx, ok = splitV4AuthHeader(headerValue)
 		}
-		result := make(map[string]string)
-		for i, name := range V4AuthHeaderRegexp.SubexpNames() {
-			if i != 0 && name != "" {
-				result[name] = match[i]
-			}
+		if !ok {
+			ctx, ok = matchV4AuthHeader(pattern.v4AuthHeader, headerValue)
 		}
-		headers := splitHeaders(result["SignatureHeaders"])
-		ctx.AccessKeyID = result["AccessKeyId"]
-		ctx.Date = result["Date"]
-		ctx.Region = result["Region"]
-		ctx.Service = result["Service"]
-
-		ctx.Signature = result["Signature"]
-
-		ctx.SignedHeaders = headers
-		ctx.SignedHeadersString = result["SignatureHeaders"]
+		if !ok {
+			return ctx, ErrHeaderMalformed
+		}
+		ctx.SignedHeaders = splitHeaders(ctx.SignedHeadersString)
 		return ctx, nil
 	}
 
 	// otherwise, see if we have all the required query parameters
 	query := r.URL.Query()
-	algorithm := query.Get("X-Amz-Algorithm")
+	algorithm := query.Get(v4QueryAlgorithmName)
 	if len(algorithm) == 0 || !strings.EqualFold(algorithm, v4authHeaderPrefix) {
 		return ctx, errors.ErrInvalidQuerySignatureAlgo
 	}
@@ -97,12 +148,12 @@ func ParseV4AuthContext(r *http.Request) (V4Auth, error) {
 	if len(credentialScope) == 0 {
 		return ctx, errors.ErrMissingCredTag
 	}
-	credsMatch := V4CredentialScopeRegexp.FindStringSubmatch(credentialScope)
+	credsMatch := pattern.v4CredentialScope.FindStringSubmatch(credentialScope)
 	if len(credsMatch) == 0 {
 		return ctx, errors.ErrCredMalformed
 	}
 	credsResult := make(map[string]string)
-	for i, name := range V4CredentialScopeRegexp.SubexpNames() {
+	for i, name := range pattern.v4CredentialScope.SubexpNames() {
 		if i != 0 && name != "" {
 			credsResult[name] = credsMatch[i]
 		}
@@ -119,11 +170,192 @@ func ParseV4AuthContext(r *http.Request) (V4Auth, error) {
 	return ctx, nil
 }
 
-func V4Verify(auth V4Auth, credentials *model.Credential, r *http.Request) error {
+// matchV4AuthHeader parses the Authorization header using authHeaderRegexp
+func matchV4AuthHeader(authHeaderRegexp *regexp.Regexp, headerValue string) (V4Auth, bool) {
+	var ctx V4Auth
+	match := authHeaderRegexp.FindStringSubmatch(headerValue)
+	if len(match) == 0 {
+		return ctx, false
+	}
+	result := make(map[string]string)
+	for i, name := range authHeaderRegexp.SubexpNames() {
+		if i != 0 && name != "" {
+			result[name] = match[i]
+		}
+	}
+	ctx.AccessKeyID = result["AccessKeyId"]
+	ctx.Date = result["Date"]
+	ctx.Region = result["Region"]
+	ctx.Service = result["Service"]
+	ctx.SignedHeadersString = result["SignatureHeaders"]
+	ctx.Signature = result["Signature"]
+	return ctx, true
+}
+
+// splitV4AuthHeader parses the Authorization header without a regular expression. It accepts exactly the headers
+// V4AuthHeaderRegexp matches with the default access key id pattern, but may reject some of them in which case the
+// header should be matched against the regular expression.
+func splitV4AuthHeader(headerValue string) (V4Auth, bool) {
+	var ctx V4Auth
+	rest := trimRegexpSpace(headerValue)
+	if !strings.HasPrefix(rest, v4authHeaderPrefix) {
+		return ctx, false
+	}
+	rest = rest[len(v4authHeaderPrefix):]
+	if len(rest) == 0 || !isRegexpSpace(rest[0]) {
+		return ctx, false
+	}
+	credential, rest, ok := splitV4AuthHeaderField(rest, "Credential", true)
+	if !ok {
+		return ctx, false
+	}
+	signedHeaders, rest, ok := splitV4AuthHeaderField(rest, "SignedHeaders", true)
+	if !ok || !isSignedHeadersString(signedHeaders) {
+		return ctx, false
+	}
+	signature, _, ok := splitV4AuthHeaderField(rest, "Signature", false)
+	if !ok || len(signature) != 64 || !isHexString(signature) {
+		return ctx, false
+	}
+
+	// AccessKeyId/Date/Region/Service/aws4_request
+	scope := strings.Split(credential, "/")
+	const scopeParts = 5
+	if len(scope) != scopeParts || scope[4] != v4scopeTerminator ||
+		!isDefaultAccessKeyID(scope[0]) || !isDateStamp(scope[1]) || !isScopeName(scope[2]) || !isScopeName(scope[3]) {
+		return ctx, false
+	}
+	ctx.AccessKeyID = scope[0]
+	ctx.Date = scope[1]
+	ctx.Region = scope[2]
+	ctx.Service = scope[3]
+	ctx.SignedHeadersString = signedHeaders
+	ctx.Signature = signature
+	return ctx, true
+}
+
+// splitV4AuthHeaderField parses "<name> = <value>" after optional white space, followed by a comma when more fields
+// are expected. It returns the value and the rest of the header after the comma.
+func splitV4AuthHeaderField(s, name string, expectComma bool) (string, string, bool) {
+	s = strings.TrimLeft(s, regexpSpace)
+	if !strings.HasPrefix(s, name) {
+		return "", "", false
+	}
+	s = strings.TrimLeft(s[len(name):], regexpSpace)
+	if len(s) == 0 || s[0] != '=' {
+		return "", "", false
+	}
+	s = strings.TrimLeft(s[1:], regexpSpace)
+	if !expectComma {
+		return s, "", !strings.ContainsAny(s, regexpSpace+",")
+	}
+	i := strings.IndexByte(s, ',')
+	if i < 0 {
+		return "", "", false
+	}
+	value := strings.TrimRight(s[:i], regexpSpace)
+	if strings.ContainsAny(value, regexpSpace) {
+		return "", "", false
+	}
+	return value, s[i+1:], true
+}
+
+// regexpSpace holds the characters matched by \s in V4AuthHeaderRegexp
+const regexpSpace = "\t\n\f\r "
+
+func isRegexpSpace(c byte) bool {
+	return strings.IndexByte(regexpSpace, c) >= 0
+}
+
+func trimRegexpSpace(s string) string {
+	return strings.Trim(s, regexpSpace)
+}
+
+// isWordByte matches \w
+func isWordByte(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
+
+// isDefaultAccessKeyID matches DefaultAccessKeyIDPattern
+func isDefaultAccessKeyID(s string) bool {
+	const minLength, maxLength = 16, 128
+	if len(s) < minLength || len(s) > maxLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isWordByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDateStamp(s string) bool {
+	if len(s) != len(v4shortTimeFormat) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isScopeName matches [\w\-]+
+func isScopeName(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isWordByte(s[i]) && s[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isSignedHeadersString matches [\w\-\;]+
+func isSignedHeadersString(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isWordByte(s[i]) && s[i] != '-' && s[i] != ';' {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// V4Verify verifies the signature of r with credentials.  The request time must be within maxClockSkew of the
+// server clock, it is not checked when maxClockSkew is zero.
+func V4Verify(auth V4Auth, credentials *model.Credential, r *http.Request, maxClockSkew time.Duration) error {
+	return v4Verify(auth, credentials, r, r.Host, maxClockSkew)
+}
+
+func v4Verify(auth V4Auth, credentials *model.Credential, r *http.Request, host string, maxClockSkew time.Duration) error {
 	ctx := &verificationCtx{
-		Request:   r,
-		Query:     r.URL.Query(),
-		AuthValue: auth,
+		Request:      r,
+		Host:         host,
+		Query:        r.URL.Query(),
+		AuthValue:    auth,
+		MaxClockSkew: maxClockSkew,
+	}
+
+	// every signed header must be present on the request
+	if err := ctx.verifySignedHeaders(); err != nil {
+		return err
 	}
 
 	canonicalRequest := ctx.buildCanonicalRequest()
@@ -131,17 +363,38 @@ func V4Verify(auth V4Auth, credentials *model.Credential, r *http.Request) error
 	if err != nil {
 		return err
 	}
-	// sign
-	signingKey := createSignature(credentials.AccessSecretKey, auth.Date, auth.Region, auth.Service)
-	signature := hex.EncodeToString(sign(signingKey, stringToSign))
-
-	// compare signatures
-	if !Equal([]byte(signature), []byte(auth.Signature)) {
+	// compare signatures in constant time over the decoded bytes, hex digits are accepted in either case
+	providedSignature, err := hex.DecodeString(auth.Signature)
+	if err != nil {
+		return errors.ErrSignatureDoesNotMatch
+	}
+	secretKey, ok := matchSecretKey(credentials, func(secretKey string) bool {
+		signingKey := getSigningKey(secretKey, auth.Date, auth.Region, auth.Service)
+		return Equal(sign(signingKey, stringToSign), providedSignature)
+	})
+	if !ok {
 		return errors.ErrSignatureDoesNotMatch
 	}
 
-	// wrap body with verifier
-	reader, err := ctx.reader(r.Body, credentials)
+	// temporary credentials are valid only together with their session token
+	if err := ctx.verifySecurityToken(credentials); err != nil {
+		return err
+	}
+
+	// reject requests signed too far from the server time
+	if err := ctx.verifyRequestTime(); err != nil {
+		return err
+	}
+
+	// presigned requests are valid only for the duration they were signed for
+	if err := ctx.verifyExpiration(); err != nil {
+		return err
+	}
+
+	// wrap body with verifier, streamed chunks are signed with the secret key that signed the request
+	signingCredentials := *credentials
+	signingCredentials.AccessSecretKey = secretKey
+	reader, err := ctx.reader(r.Body, &signingCredentials)
 	if err != nil {
 		return err
 	}
@@ -156,10 +409,22 @@ func V4Verify(auth V4Auth, credentials *model.Credential, r *http.Request) error
 	return nil
 }
 
+// V4VerifyStreaming returns a reader of the payload of body, sent in the aws-chunked encoding of a streaming V4
+// signed request whose seed signature, region and service are taken from auth.  amzDate is the date the request
+// was signed at.  Chunk signatures are verified as the chunks are read, so body is never buffered: reading fails
+// with ErrSignatureDoesNotMatch at the end of the first chunk that does not match, and with ErrIncompleteBody
+// when the payload is not decodedContentLength bytes long.  A negative decodedContentLength skips the length check.
+func V4VerifyStreaming(body io.ReadCloser, auth V4Auth, credentials *model.Credential, amzDate string, decodedContentLength int64) (io.ReadCloser, error) {
+	return newSignV4ChunkedReader(body, amzDate, auth, credentials, decodedContentLength)
+}
+
 type verificationCtx struct {
-	Request   *http.Request
-	Query     url.Values
-	AuthValue V4Auth
+	Request *http.Request
+	// Host is the host the client sent the request to
+	Host         string
+	Query        url.Values
+	AuthValue    V4Auth
+	MaxClockSkew time.Duration
 }
 
 func (ctx *verificationCtx) queryEscape(str string) string {
@@ -167,17 +432,30 @@ func (ctx *verificationCtx) queryEscape(str string) string {
 }
 
 func (ctx *verificationCtx) canonicalizeQueryString() string {
-	queryNames := make([]string, 0, len(ctx.Query))
-	for k := range ctx.Query {
-		if k == v4SignatureHeader {
+	type queryParam struct {
+		key, value string
+	}
+	params := make([]queryParam, 0, len(ctx.Query))
+	for k, values := range ctx.Query {
+		// the signature is not part of the request it signs
+		if strings.EqualFold(k, v4SignatureHeader) {
 			continue
 		}
-		queryNames = append(queryNames, k)
+		// repeated parameters are all part of the canonical query string
+		for _, v := range values {
+			params = append(params, queryParam{key: ctx.queryEscape(k), value: ctx.queryEscape(v)})
+		}
 	}
-	sort.Strings(queryNames)
-	buf := make([]string, len(queryNames))
-	for i, key := range queryNames {
-		buf[i] = fmt.Sprintf("%s=%s", ctx.queryEscape(key), ctx.queryEscape(ctx.Query.Get(key)))
+	// sort by key name, parameters with the same name are sorted by value
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].key != params[j].key {
+			return params[i].key < params[j].key
+		}
+		return params[i].value < params[j].value
+	})
+	buf := make([]string, len(params))
+	for i, param := range params {
+		buf[i] = param.key + "=" + param.value
 	}
 	return strings.Join(buf, "&")
 }
@@ -188,9 +466,14 @@ func (ctx *verificationCtx) canonicalizeHeaders(headers []string) string {
 		var value string
 		if strings.EqualFold(strings.ToLower(header), "host") {
 			// in Go, Host is removed from the headers and is promoted to request.Host for some reason
-			value = ctx.Request.Host
+			value = canonicalHost(ctx.Host)
 		} else {
-			value = getInsensitiveHeader(ctx.Request, header)
+			// repeated headers are joined with commas, in the order they appear
+			values := getInsensitiveHeaderValues(ctx.Request, header)
+			for i := range values {
+				values[i] = ctx.trimAll(values[i])
+			}
+			value = strings.Join(values, ",")
 		}
 		buf.WriteString(header)
 		buf.WriteString(":")
@@ -199,6 +482,25 @@ func (ctx *verificationCtx) canonicalizeHeaders(headers []string) string {
 	}
 	return buf.String()
 }
+
+// canonicalHost returns the host the way SDKs sign it: lowercase and without the default http or https port.
+// IPv6 literals keep their brackets.
+func canonicalHost(host string) string {
+	host = strings.ToLower(host)
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		// no port
+		return host
+	}
+	if port != "80" && port != "443" {
+		return host
+	}
+	if strings.Contains(hostname, ":") {
+		return "[" + hostname + "]"
+	}
+	return hostname
+}
+
 func (ctx *verificationCtx) trimAll(str string) string {
 	str = strings.TrimSpace(str)
 	inSpace := false
@@ -219,6 +521,45 @@ func (ctx *verificationCtx) trimAll(str string) string {
 	return buf.String()
 }
 
+func (ctx *verificationCtx) verifySignedHeaders() error {
+	const hostHeader = "host"
+	if !ctx.isSignedHeader(hostHeader) {
+		return errors.ErrUnsignedHeaders
+	}
+	for _, header := range ctx.AuthValue.SignedHeaders {
+		if strings.EqualFold(header, hostHeader) {
+			// in Go, Host is removed from the headers and is promoted to request.Host
+			if len(ctx.Host) == 0 {
+				return errors.ErrMissingSignedHeader
+			}
+			continue
+		}
+		if !hasInsensitiveHeader(ctx.Request, header) {
+			return errors.ErrMissingSignedHeader
+		}
+	}
+	return nil
+}
+
+func getInsensitiveHeaderValues(r *http.Request, headerName string) []string {
+	var values []string
+	for k, v := range r.Header {
+		if strings.EqualFold(k, headerName) {
+			values = append(values, v...)
+		}
+	}
+	return values
+}
+
+func hasInsensitiveHeader(r *http.Request, headerName string) bool {
+	for k := range r.Header {
+		if strings.EqualFold(k, headerName) {
+			return true
+		}
+	}
+	return false
+}
+
 func getInsensitiveHeader(r *http.Request, headerName string) string {
 	for k, v := range r.Header {
 		if strings.EqualFold(k, headerName) {
@@ -239,7 +580,7 @@ func (ctx *verificationCtx) payloadHash() string {
 func (ctx *verificationCtx) buildCanonicalRequest() string {
 	// Step 1: Canonical request
 	method := ctx.Request.Method
-	canonicalURI := EncodePath(ctx.Request.URL.Path)
+	canonicalURI := canonicalizePath(ctx.Request.URL)
 	canonicalQueryString := ctx.canonicalizeQueryString()
 	canonicalHeaders := ctx.canonicalizeHeaders(ctx.AuthValue.SignedHeaders)
 	signedHeaders := ctx.AuthValue.SignedHeadersString
@@ -255,9 +596,29 @@ func (ctx *verificationCtx) buildCanonicalRequest() string {
 	return canonicalRequest
 }
 
+// canonicalizePath returns the canonical URI of the request path as it was sent. The path is encoded segment by
+// segment, so empty segments, trailing slashes and slashes encoded inside a segment are signed as the client sent them.
+func canonicalizePath(u *url.URL) string {
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			// not a valid escaping, encode the decoded path instead
+			return EncodePath(u.Path)
+		}
+		// a slash inside a segment was sent encoded
+		segments[i] = strings.ReplaceAll(EncodePath(unescaped), "/", "%2F")
+	}
+	canonicalURI := strings.Join(segments, "/")
+	if canonicalURI == "" {
+		return "/"
+	}
+	return canonicalURI
+}
+
 func (ctx *verificationCtx) getAmzDate() (string, error) {
 	// https://docs.aws.amazon.com/general/latest/gr/sigv4-date-handling.html
-	amzDate := ctx.Request.URL.Query().Get("X-Amz-Date")
+	amzDate := ctx.Query.Get(v4QueryDateName)
 	if len(amzDate) == 0 {
 		amzDate = ctx.Request.Header.Get("x-amz-date")
 		if len(amzDate) == 0 {
@@ -280,14 +641,101 @@ func (ctx *verificationCtx) getAmzDate() (string, error) {
 		return "", errors.ErrMalformedCredentialDate
 	}
 
-	// ensure same date
-	if sigTS.Year() != ts.Year() || sigTS.Month() != ts.Month() || sigTS.Day() != ts.Day() {
+	// ensure same date, the credential scope may fall on an adjacent day when signed close to midnight
+	if !sameDay(sigTS, ts) && !sameDay(sigTS, ts.Add(ctx.MaxClockSkew)) && !sameDay(sigTS, ts.Add(-ctx.MaxClockSkew)) {
 		return "", errors.ErrMalformedCredentialDate
 	}
 
 	return amzDate, nil
 }
 
+func (ctx *verificationCtx) isSignedHeader(headerName string) bool {
+	for _, header := range ctx.AuthValue.SignedHeaders {
+		if strings.EqualFold(header, headerName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctx *verificationCtx) verifySecurityToken(creds *model.Credential) error {
+	// presigned requests pass the token as a query parameter, covered by the canonical query string
+	token := ctx.Query.Get(v4SecurityTokenName)
+	if len(token) == 0 {
+		token = getInsensitiveHeader(ctx.Request, v4SecurityTokenName)
+		if len(token) > 0 && !ctx.isSignedHeader(v4SecurityTokenName) {
+			return errors.ErrUnsignedHeaders
+		}
+	}
+	if len(token) == 0 && len(creds.SessionToken) == 0 {
+		return nil
+	}
+	if !Equal([]byte(token), []byte(creds.SessionToken)) {
+		return errors.ErrInvalidToken
+	}
+	return nil
+}
+
+func sameDay(t1, t2 time.Time) bool {
+	return t1.Year() == t2.Year() && t1.Month() == t2.Month() && t1.Day() == t2.Day()
+}
+
+func (ctx *verificationCtx) verifyRequestTime() error {
+	// presigned requests are bounded by their expiration instead
+	if ctx.MaxClockSkew <= 0 || ctx.isPresigned() {
+		return nil
+	}
+	amzDate, err := ctx.getAmzDate()
+	if err != nil {
+		return err
+	}
+	ts, err := time.Parse(v4timeFormat, amzDate)
+	if err != nil {
+		return errors.ErrMalformedDate
+	}
+	if skew := time.Since(ts); skew > ctx.MaxClockSkew || skew < -ctx.MaxClockSkew {
+		return errors.ErrRequestTimeTooSkewed
+	}
+	return nil
+}
+
+func (ctx *verificationCtx) verifyExpiration() error {
+	amzDate := ctx.Query.Get(v4QueryDateName)
+	expiresStr := ctx.Query.Get(v4QueryExpiresName)
+	if len(amzDate) == 0 || len(expiresStr) == 0 {
+		return nil
+	}
+	expiresSeconds, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return errors.ErrMalformedExpires
+	}
+	if expiresSeconds < 0 {
+		return errors.ErrNegativeExpires
+	}
+	expires := time.Duration(expiresSeconds) * time.Second
+	if expires > v4MaxExpires {
+		return errors.ErrMaximumExpires
+	}
+	signTime, err := time.Parse(v4timeFormat, amzDate)
+	if err != nil {
+		return errors.ErrMalformedPresignedDate
+	}
+	now := time.Now()
+	if now.After(signTime.Add(expires + ctx.MaxClockSkew)) {
+		return errors.ErrExpiredPresignRequest
+	}
+	// a date in the future would extend the validity of the request past the longest allowed period
+	if signTime.After(now.Add(ctx.MaxClockSkew)) {
+		return errors.ErrRequestNotReadyYet
+	}
+	return nil
+}
+
+// isPresigned returns true if the request is signed by query parameters rather than by the Authorization header
+func (ctx *verificationCtx) isPresigned() bool {
+	return len(ctx.Query.Get(v4QueryAlgorithmName)) > 0 || len(ctx.Query.Get("X-Amz-Credential")) > 0
+}
+
 func sign(key []byte, msg string) []byte {
 	h := hmac.New(sha256.New, key)
 	_, _ = h.Write([]byte(msg))
@@ -302,6 +750,23 @@ func createSignature(key, dateStamp, region, service string) []byte {
 	return kSigning
 }
 
+// getSigningKey returns the signing key derived for the given scope, derived keys change daily so they are cached
+func getSigningKey(key, dateStamp, region, service string) []byte {
+	h := sha256.New()
+	for _, field := range []string{key, dateStamp, region, service} {
+		_, _ = h.Write([]byte(field))
+		_, _ = h.Write([]byte{0})
+	}
+	cacheKey := hex.EncodeToString(h.Sum(nil))
+	signingKey, err := signingKeyCache.GetOrSet(cacheKey, func() (interface{}, error) {
+		return createSignature(key, dateStamp, region, service), nil
+	})
+	if err != nil {
+		return createSignature(key, dateStamp, region, service)
+	}
+	return signingKey.([]byte)
+}
+
 func (ctx *verificationCtx) buildSignedString(canonicalRequest string) (string, error) {
 	// Step 2: Create string to sign
 	algorithm := v4authHeaderPrefix
@@ -361,10 +826,19 @@ func (ctx *verificationCtx) reader(reader io.ReadCloser, creds *model.Credential
 		if err != nil {
 			return nil, err
 		}
-		chunkReader, err := newSignV4ChunkedReader(bufio.NewReader(reader), amzDate, ctx.AuthValue, creds)
+		decodedContentLength := int64(-1)
+		if _, ok := ctx.Request.Header[AmzDecodedContentLength]; ok {
+			decodedContentLength, err = ctx.contentLength()
+			if err != nil {
+				return nil, err
+			}
+		}
+		chunkReader, err := V4VerifyStreaming(reader, ctx.AuthValue, creds, amzDate, decodedContentLength)
 		if err != nil {
 			return nil, err
 		}
+		// the body is decoded from here on, so it should no longer be described as aws-chunked
+		ctx.removeChunkedContentEncoding()
 		return chunkReader, nil
 	}
 
@@ -374,15 +848,48 @@ func (ctx *verificationCtx) reader(reader io.ReadCloser, creds *model.Credential
 	return NewSha265Reader(reader, ctx.payloadHash())
 }
 
+func (ctx *verificationCtx) removeChunkedContentEncoding() {
+	const chunkedContentEncoding = "aws-chunked"
+	encodings := strings.Split(ctx.Request.Header.Get("Content-Encoding"), ",")
+	remaining := make([]string, 0, len(encodings))
+	for _, encoding := range encodings {
+		encoding = strings.TrimSpace(encoding)
+		if encoding != "" && !strings.EqualFold(encoding, chunkedContentEncoding) {
+			remaining = append(remaining, encoding)
+		}
+	}
+	if len(remaining) == 0 {
+		ctx.Request.Header.Del("Content-Encoding")
+		return
+	}
+	ctx.Request.Header.Set("Content-Encoding", strings.Join(remaining, ","))
+}
+
 type V4Authenticator struct {
 	request *http.Request
 	ctx     V4Auth
+	// MaxClockSkew is the tolerance used when checking the request time against the server clock.
+	// Zero disables checking the request time of non-presigned requests.
+	MaxClockSkew time.Duration
+	// ForwardedHostHeaders are headers in which a proxy passes the host the client sent the request to, such as
+	// X-Forwarded-Host. The first one present on the request is verified instead of the request host.
+	// Set them only when every request passes through a proxy that overwrites them, clients can set them too.
+	ForwardedHostHeaders []string
+	// AllowedRegions returns the regions requests to Repository may be signed for, Verify fails with ErrWrongRegion
+	// for requests signed for any other region. nil accepts every region.
+	AllowedRegions RegionsLookup
+	// Repository is the repository the request addresses, empty if it does not address one
+	Repository string
+	// AccessKeyIDPattern matches the access key ids of signatures, nil matches DefaultAccessKeyIDPattern
+	AccessKeyIDPattern *AccessKeyIDPattern
+	// MetricsRecorder is notified of the result of Verify, nil disables recording
+	MetricsRecorder MetricsRecorder
 }
 
 func (a *V4Authenticator) Parse() (SigContext, error) {
 	var ctx V4Auth
 	var err error
-	ctx, err = ParseV4AuthContext(a.request)
+	ctx, err = parseV4AuthContext(a.request, accessKeyIDPatternOrDefault(a.AccessKeyIDPattern))
 	if err != nil {
 		return ctx, err
 	}
@@ -395,13 +902,61 @@ func (a *V4Authenticator) String() string {
 }
 
 func (a *V4Authenticator) Verify(creds *model.Credential, bareDomain string) error {
-	err := V4Verify(a.ctx, creds, a.request)
+	err := a.verifyRegion()
+	if err == nil {
+		err = v4Verify(a.ctx, creds, a.request, a.host(), a.MaxClockSkew)
+	}
+	recordVerification(a.MetricsRecorder, err)
 	return err
 }
 
-func NewV4Authenticator(r *http.Request) SigAuthenticator {
+// verifyRegion checks that the request was signed for one of the regions allowed for its repository.
+// Clients differ in the region they sign for by default, so any of several regions may be allowed.
+func (a *V4Authenticator) verifyRegion() error {
+	return verifyRegion(a.AllowedRegions, a.Repository, a.ctx.Region)
+}
+
+// verifyRegion checks that region is one of the regions lookup allows for repository.  An empty region, of
+// signatures that are not scoped to a region, is allowed only when every region is.
+func verifyRegion(lookup RegionsLookup, repository, region string) error {
+	if lookup == nil {
+		return nil
+	}
+	regions := lookup(repository)
+	if len(regions) == 0 {
+		return nil
+	}
+	for _, allowed := range regions {
+		if region == allowed {
+			return nil
+		}
+	}
+	if region == "" {
+		return fmt.Errorf("%w: signature is not scoped to a region", ErrWrongRegion)
+	}
+	return fmt.Errorf("%w: %s", ErrWrongRegion, region)
+}
+
+// host returns the host the client sent the request to, taken from the forwarded host headers when present
+func (a *V4Authenticator) host() string {
+	for _, header := range a.ForwardedHostHeaders {
+		value := a.request.Header.Get(header)
+		if value == "" {
+			continue
+		}
+		// each proxy on the way appends the host it received, the first one is the host the client used
+		if i := strings.IndexByte(value, ','); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value)
+	}
+	return a.request.Host
+}
+
+func NewV4Authenticator(r *http.Request) *V4Authenticator {
 	return &V4Authenticator{
-		request: r,
-		ctx:     V4Auth{},
+		request:      r,
+		ctx:          V4Auth{},
+		MaxClockSkew: DefaultMaxClockSkew,
 	}
 }
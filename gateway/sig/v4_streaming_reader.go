@@ -62,7 +62,7 @@ func getChunkSignature(cred *model.Credential, seedSignature string, region stri
 		hashedChunk
 
 	// Get hmac signing key.
-	signingKey := createSignature(cred.AccessSecretKey, date.Format(v4shortTimeFormat), region, service)
+	signingKey := getSigningKey(cred.AccessSecretKey, date.Format(v4shortTimeFormat), region, service)
 
 	// Calculate signature.
 	newSignature := hex.EncodeToString(sign(signingKey, stringToSign))
@@ -89,26 +89,30 @@ var (
 //
 // NewChunkedReader is not needed by normal applications. The http package
 // automatically decodes chunking when reading response bodies.
-func newSignV4ChunkedReader(reader *bufio.Reader, amzDate string, auth V4Auth, creds *model.Credential) (io.ReadCloser, error) {
+// A negative decodedContentLength skips checking the length of the decoded payload.
+func newSignV4ChunkedReader(body io.ReadCloser, amzDate string, auth V4Auth, creds *model.Credential, decodedContentLength int64) (io.ReadCloser, error) {
 	seedDate, err := time.Parse(v4timeFormat, amzDate)
 	if err != nil {
 		return nil, err
 	}
 	return &s3ChunkedReader{
-		reader:            reader,
-		cred:              creds,
-		seedSignature:     auth.Signature,
-		seedDate:          seedDate,
-		region:            auth.Region,
-		service:           auth.Service,
-		chunkSHA256Writer: sha256.New(),
-		state:             readChunkHeader,
+		body:                 body,
+		reader:               bufio.NewReader(body),
+		cred:                 creds,
+		seedSignature:        auth.Signature,
+		seedDate:             seedDate,
+		region:               auth.Region,
+		service:              auth.Service,
+		chunkSHA256Writer:    sha256.New(),
+		state:                readChunkHeader,
+		decodedContentLength: decodedContentLength,
 	}, nil
 }
 
 // Represents the overall state that is required for decoding a
 // AWS Signature V4 chunked reader.
 type s3ChunkedReader struct {
+	body              io.ReadCloser
 	reader            *bufio.Reader
 	cred              *model.Credential
 	seedSignature     string
@@ -121,6 +125,9 @@ type s3ChunkedReader struct {
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
 	n                 uint64    // Unread bytes in chunk
 	err               error
+	// decodedContentLength is the expected payload length, negative if unknown
+	decodedContentLength int64
+	decoded              int64 // Payload bytes in chunks read so far
 }
 
 // Read chunk reads the chunk token signature portion.
@@ -171,7 +178,7 @@ func (cs chunkState) String() string {
 }
 
 func (cr *s3ChunkedReader) Close() (err error) {
-	return nil
+	return cr.body.Close()
 }
 
 // Read - implements `io.Reader`, which transparently decodes
@@ -190,6 +197,12 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			if cr.err != nil {
 				return 0, cr.err
 			}
+			cr.decoded += int64(cr.n)
+			if cr.decodedContentLength >= 0 && cr.decoded > cr.decodedContentLength {
+				// the payload read so far was verified, stop before the chunk that exceeds the length
+				cr.state = eofChunk
+				return n, gwerrors.ErrIncompleteBody
+			}
 			cr.state = readChunk
 		case readChunkTrailer:
 			cr.err = readCRLF(cr.reader)
@@ -251,6 +264,9 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 				cr.state = readChunkHeader
 			}
 		case eofChunk:
+			if cr.decodedContentLength >= 0 && cr.decoded != cr.decodedContentLength {
+				return n, gwerrors.ErrIncompleteBody
+			}
 			return n, io.EOF
 		}
 	}
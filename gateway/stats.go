@@ -11,3 +11,17 @@ var requestHistograms = promauto.NewHistogramVec(
 		Help: "request durations for lakeFS storage gateway",
 	},
 	[]string{"operation", "code"})
+
+var signatureVerificationsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gateway_signature_verifications_total",
+		Help: "signature verifications of lakeFS storage gateway requests by result",
+	},
+	[]string{"result"})
+
+// SigMetricsRecorder counts the signature verifications of the gateway by result
+type SigMetricsRecorder struct{}
+
+func (SigMetricsRecorder) RecordVerification(result string) {
+	signatureVerificationsCounter.WithLabelValues(result).Inc()
+}
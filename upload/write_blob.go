@@ -14,11 +14,14 @@ type Blob struct {
 	Checksum        string
 	DedupID         string
 	Size            int64
+	// Sha256 and CRC32C are the raw digests of the content, used to verify client provided checksums
+	Sha256 []byte
+	CRC32C []byte
 }
 
 func WriteBlob(adapter block.Adapter, bucketName string, body io.Reader, contentLength int64, opts block.PutOpts) (*Blob, error) {
 	// handle the upload itself
-	hashReader := block.NewHashingReader(body, block.HashFunctionMD5, block.HashFunctionSHA256)
+	hashReader := block.NewHashingReader(body, block.HashFunctionMD5, block.HashFunctionSHA256, block.HashFunctionCRC32C)
 	uid := uuid.New()
 	address := hex.EncodeToString(uid[:])
 	err := adapter.Put(block.ObjectPointer{
@@ -29,11 +32,14 @@ func WriteBlob(adapter block.Adapter, bucketName string, body io.Reader, content
 		return nil, err
 	}
 	checksum := hex.EncodeToString(hashReader.Md5.Sum(nil))
-	dedupID := hex.EncodeToString(hashReader.Sha256.Sum(nil))
+	sha256 := hashReader.Sha256.Sum(nil)
+	dedupID := hex.EncodeToString(sha256)
 	return &Blob{
 		PhysicalAddress: address,
 		Checksum:        checksum,
 		DedupID:         dedupID,
 		Size:            hashReader.CopiedSize,
+		Sha256:          sha256,
+		CRC32C:          hashReader.Crc32c.Sum(nil),
 	}, nil
 }